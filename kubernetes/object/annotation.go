@@ -0,0 +1,118 @@
+package object
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// compressedPrefix marks annotation values that are gzip compressed and base64 encoded, it cannot be the start of a
+// JSON document.
+const compressedPrefix = "gzip+base64:"
+
+// AnnotationJSONOptions defines options needed to encode a value into an annotation.
+type AnnotationJSONOptions struct {
+	compressThreshold int
+}
+
+// WithCompressionThreshold sets the size in bytes above which the JSON payload is gzip compressed and base64
+// encoded, a negative value disables the compression.
+// The default value is -1.
+func WithCompressionThreshold(threshold int) func(*AnnotationJSONOptions) {
+	return func(o *AnnotationJSONOptions) {
+		o.compressThreshold = threshold
+	}
+}
+
+// GetAnnotationJSON decodes the JSON (optionally compressed) value of the annotation, returns false if the object does
+// not contain the annotation.
+//
+// eg:
+//
+//	state, found, err := GetAnnotationJSON[State](obj, "example.com/last-observed-state")
+func GetAnnotationJSON[T any](obj metav1.Object, key string) (T, bool, error) {
+	var result T
+	value, ok := obj.GetAnnotations()[key]
+	if !ok {
+		return result, false, nil
+	}
+
+	data := []byte(value)
+	if strings.HasPrefix(value, compressedPrefix) {
+		var err error
+		if data, err = decompress(strings.TrimPrefix(value, compressedPrefix)); err != nil {
+			return result, true, fmt.Errorf("annotation %s: %v", key, err)
+		}
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, true, fmt.Errorf("annotation %s: %v", key, err)
+	}
+	return result, true, nil
+}
+
+// SetAnnotationJSON encodes the value to JSON and stores it in the annotation, returns true if the object's
+// annotations are updated. An error is returned if the total size of the object's annotations would exceed the
+// 256KiB limit, in which case the object is left untouched.
+func SetAnnotationJSON(obj metav1.Object, key string, v interface{}, options ...func(*AnnotationJSONOptions)) (bool, error) {
+	opts := &AnnotationJSONOptions{
+		compressThreshold: -1,
+	}
+	for _, f := range options {
+		f(opts)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return false, err
+	}
+	value := string(data)
+	if opts.compressThreshold >= 0 && len(data) > opts.compressThreshold {
+		compressed, err := compress(data)
+		if err != nil {
+			return false, err
+		}
+		value = compressedPrefix + compressed
+	}
+
+	annotations := make(map[string]string, len(obj.GetAnnotations())+1)
+	for k, v := range obj.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[key] = value
+	if err = apivalidation.ValidateAnnotationsSize(annotations); err != nil {
+		return false, fmt.Errorf("annotation %s: %v", key, err)
+	}
+	return AddAnnotation(obj, key, value), nil
+}
+
+func compress(data []byte) (string, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func decompress(value string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close() // nolint
+	return io.ReadAll(r)
+}
@@ -0,0 +1,157 @@
+package object
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type annotationState struct {
+	Replicas int      `json:"replicas"`
+	Images   []string `json:"images"`
+}
+
+func TestSetAnnotationJSON(t *testing.T) {
+	state := annotationState{Replicas: 2, Images: []string{strings.Repeat("nginx", 100)}}
+
+	tests := []struct {
+		name       string
+		obj        metav1.Object
+		value      interface{}
+		options    []func(*AnnotationJSONOptions)
+		isErr      bool
+		updated    bool
+		compressed bool
+	}{
+		{
+			name:    "normal test",
+			obj:     &corev1.ConfigMap{},
+			value:   state,
+			updated: true,
+		},
+		{
+			name:       "compression test",
+			obj:        &corev1.ConfigMap{},
+			value:      state,
+			options:    []func(*AnnotationJSONOptions){WithCompressionThreshold(100)},
+			updated:    true,
+			compressed: true,
+		},
+		{
+			name:    "below threshold test",
+			obj:     &corev1.ConfigMap{},
+			value:   annotationState{Replicas: 1},
+			options: []func(*AnnotationJSONOptions){WithCompressionThreshold(100)},
+			updated: true,
+		},
+		{
+			name: "too large test",
+			obj: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"aa": strings.Repeat("x", 256*1024-100),
+					},
+				},
+			},
+			value: state,
+			isErr: true,
+		},
+		{
+			name:  "unsupported value test",
+			obj:   &corev1.ConfigMap{},
+			value: make(chan int),
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := SetAnnotationJSON(tt.obj, "state", tt.value, tt.options...)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s SetAnnotationJSON() unexpected error: %v", tt.name, err)
+			}
+			if updated != tt.updated {
+				t.Errorf("SetAnnotationJSON() = %v, want %v", updated, tt.updated)
+			}
+			if tt.isErr {
+				if ContainsAnnotation(tt.obj, "state") {
+					t.Errorf("SetAnnotationJSON() modified the object on error")
+				}
+				return
+			}
+			if got := strings.HasPrefix(GetAnnotation(tt.obj, "state"), compressedPrefix); got != tt.compressed {
+				t.Errorf("SetAnnotationJSON() compressed = %v, want %v", got, tt.compressed)
+			}
+
+			got, found, err := GetAnnotationJSON[annotationState](tt.obj, "state")
+			if err != nil || !found {
+				t.Fatalf("%s GetAnnotationJSON() = %v, %v", tt.name, found, err)
+			}
+			if !reflect.DeepEqual(got, tt.value) {
+				t.Errorf("GetAnnotationJSON() = %v, want %v", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestGetAnnotationJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		obj    metav1.Object
+		isErr  bool
+		found  bool
+		wanted annotationState
+	}{
+		{
+			name: "normal test",
+			obj: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"state": `{"replicas":3}`},
+				},
+			},
+			found:  true,
+			wanted: annotationState{Replicas: 3},
+		},
+		{
+			name:  "not found test",
+			obj:   &corev1.ConfigMap{},
+			found: false,
+		},
+		{
+			name: "invalid json test",
+			obj: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"state": `{`},
+				},
+			},
+			found: true,
+			isErr: true,
+		},
+		{
+			name: "invalid compressed value test",
+			obj: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"state": compressedPrefix + "eA=="},
+				},
+			},
+			found: true,
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found, err := GetAnnotationJSON[annotationState](tt.obj, "state")
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s GetAnnotationJSON() unexpected error: %v", tt.name, err)
+			}
+			if found != tt.found {
+				t.Errorf("GetAnnotationJSON() found = %v, want %v", found, tt.found)
+			}
+			if !tt.isErr && !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("GetAnnotationJSON() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package object
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// LastAppliedConfigAnnotation is the annotation kubectl uses to store the last applied configuration.
+const LastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Changes describes what changed between two versions of an object.
+type Changes struct {
+	Labels          bool
+	Annotations     bool
+	Finalizers      bool
+	OwnerReferences bool
+	Generation      bool
+	// Spec reports changes of all the top-level fields other than apiVersion, kind, metadata and status, so that
+	// objects without a spec field (eg: ConfigMap data) are covered as well.
+	Spec   bool
+	Status bool
+}
+
+// Any determines whether anything changed.
+func (c Changes) Any() bool {
+	return c.MetadataChanged() || c.Generation || c.Spec || c.Status
+}
+
+// MetadataChanged determines whether the labels, annotations, finalizers or owner references changed.
+func (c Changes) MetadataChanged() bool {
+	return c.Labels || c.Annotations || c.Finalizers || c.OwnerReferences
+}
+
+// MetadataOnly determines whether only the labels, annotations, finalizers or owner references changed.
+func (c Changes) MetadataOnly() bool {
+	return c.MetadataChanged() && !c.Generation && !c.Spec && !c.Status
+}
+
+// ChangeOptions defines options needed to detect the changes of an object.
+type ChangeOptions struct {
+	ignoredAnnotations sets.Set[string]
+	ignoredLabels      sets.Set[string]
+}
+
+// WithIgnoredAnnotations sets the annotations whose changes are ignored.
+// The default value is kubectl.kubernetes.io/last-applied-configuration.
+func WithIgnoredAnnotations(keys ...string) func(*ChangeOptions) {
+	return func(o *ChangeOptions) {
+		o.ignoredAnnotations = sets.New(keys...)
+	}
+}
+
+// WithIgnoredLabels sets the labels whose changes are ignored.
+// The default value is empty.
+func WithIgnoredLabels(keys ...string) func(*ChangeOptions) {
+	return func(o *ChangeOptions) {
+		o.ignoredLabels = sets.New(keys...)
+	}
+}
+
+// DetectChanges compares two versions of an object and reports what changed, it works on typed and unstructured
+// objects.
+//
+// eg:
+//
+//	changes, err := DetectChanges(oldObj, newObj)
+//	if err == nil && changes.MetadataOnly() {
+//		// skip the reconciliation
+//	}
+func DetectChanges(oldObj, newObj runtime.Object, options ...func(*ChangeOptions)) (Changes, error) {
+	opts := &ChangeOptions{
+		ignoredAnnotations: sets.New(LastAppliedConfigAnnotation),
+		ignoredLabels:      sets.New[string](),
+	}
+	for _, f := range options {
+		f(opts)
+	}
+
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		return Changes{}, err
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil {
+		return Changes{}, err
+	}
+	oldContent, err := unstructuredContent(oldObj)
+	if err != nil {
+		return Changes{}, err
+	}
+	newContent, err := unstructuredContent(newObj)
+	if err != nil {
+		return Changes{}, err
+	}
+
+	return Changes{
+		Labels:          !equalIgnoring(oldMeta.GetLabels(), newMeta.GetLabels(), opts.ignoredLabels),
+		Annotations:     !equalIgnoring(oldMeta.GetAnnotations(), newMeta.GetAnnotations(), opts.ignoredAnnotations),
+		Finalizers:      !equality.Semantic.DeepEqual(oldMeta.GetFinalizers(), newMeta.GetFinalizers()),
+		OwnerReferences: !equality.Semantic.DeepEqual(oldMeta.GetOwnerReferences(), newMeta.GetOwnerReferences()),
+		Generation:      oldMeta.GetGeneration() != newMeta.GetGeneration(),
+		Spec:            !reflect.DeepEqual(specFields(oldContent), specFields(newContent)),
+		Status:          !reflect.DeepEqual(oldContent["status"], newContent["status"]),
+	}, nil
+}
+
+// equalIgnoring determines whether two string maps are equal without considering the ignored keys, nil and empty
+// maps are equal.
+func equalIgnoring(a, b map[string]string, ignored sets.Set[string]) bool {
+	for k, v := range a {
+		if ignored.Has(k) {
+			continue
+		}
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	for k := range b {
+		if ignored.Has(k) {
+			continue
+		}
+		if _, ok := a[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// specFields returns the top-level fields of the object content other than apiVersion, kind, metadata and status.
+func specFields(content map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(content))
+	for k, v := range content {
+		switch k {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
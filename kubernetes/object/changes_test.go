@@ -0,0 +1,136 @@
+package object
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestDetectChanges(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "deploy",
+			Generation: 1,
+			Labels:     map[string]string{"app": "web"},
+			Annotations: map[string]string{
+				LastAppliedConfigAnnotation: "{}",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		oldObj       runtime.Object
+		newObj       func() runtime.Object
+		options      []func(*ChangeOptions)
+		wanted       Changes
+		metadataOnly bool
+	}{
+		{
+			name:   "no changes test",
+			oldObj: deploy,
+			newObj: func() runtime.Object {
+				obj := deploy.DeepCopy()
+				obj.Annotations[LastAppliedConfigAnnotation] = `{"spec":{}}`
+				return obj
+			},
+			wanted: Changes{},
+		},
+		{
+			name:   "metadata only test",
+			oldObj: deploy,
+			newObj: func() runtime.Object {
+				obj := deploy.DeepCopy()
+				obj.Labels["tier"] = "frontend"
+				obj.Finalizers = []string{"example.com/finalizer"}
+				return obj
+			},
+			wanted:       Changes{Labels: true, Finalizers: true},
+			metadataOnly: true,
+		},
+		{
+			name:   "ignored label test",
+			oldObj: deploy,
+			newObj: func() runtime.Object {
+				obj := deploy.DeepCopy()
+				obj.Labels["tier"] = "frontend"
+				return obj
+			},
+			options: []func(*ChangeOptions){WithIgnoredLabels("tier")},
+			wanted:  Changes{},
+		},
+		{
+			name:   "spec test",
+			oldObj: deploy,
+			newObj: func() runtime.Object {
+				obj := deploy.DeepCopy()
+				obj.Generation = 2
+				obj.Spec.Replicas = ptr.To[int32](2)
+				obj.Annotations["aa"] = "bb"
+				return obj
+			},
+			wanted: Changes{Annotations: true, Generation: true, Spec: true},
+		},
+		{
+			name:   "status test",
+			oldObj: deploy,
+			newObj: func() runtime.Object {
+				obj := deploy.DeepCopy()
+				obj.Status.ReadyReplicas = 1
+				return obj
+			},
+			wanted: Changes{Status: true},
+		},
+		{
+			name:   "data test",
+			oldObj: &corev1.ConfigMap{Data: map[string]string{"aa": "bb"}},
+			newObj: func() runtime.Object {
+				return &corev1.ConfigMap{Data: map[string]string{"aa": "cc"}}
+			},
+			wanted: Changes{Spec: true},
+		},
+		{
+			name: "unstructured test",
+			oldObj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test"},
+				"spec":     map[string]interface{}{"aa": "bb"},
+			}},
+			newObj: func() runtime.Object {
+				return &unstructured.Unstructured{Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name":            "test",
+						"ownerReferences": []interface{}{map[string]interface{}{"uid": "1"}},
+					},
+					"spec": map[string]interface{}{"aa": "bb"},
+				}}
+			},
+			wanted:       Changes{OwnerReferences: true},
+			metadataOnly: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectChanges(tt.oldObj, tt.newObj(), tt.options...)
+			if err != nil {
+				t.Fatalf("%s DetectChanges() unexpected error: %v", tt.name, err)
+			}
+			if got != tt.wanted {
+				t.Errorf("DetectChanges() = %+v, want %+v", got, tt.wanted)
+			}
+			if got.MetadataOnly() != tt.metadataOnly {
+				t.Errorf("MetadataOnly() = %v, want %v", got.MetadataOnly(), tt.metadataOnly)
+			}
+			if got.Any() != (tt.wanted != Changes{}) {
+				t.Errorf("Any() = %v", got.Any())
+			}
+		})
+	}
+}
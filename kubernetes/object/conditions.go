@@ -0,0 +1,239 @@
+package object
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// ReadyCondition is the type of the condition that summarises the readiness of an object.
+	ReadyCondition = "Ready"
+
+	// ReasonAllReady is the reason of the summary condition when all its dependencies are true.
+	ReasonAllReady = "AllReady"
+	// ReasonNotReady is the reason of the summary condition when one of its dependencies is false and has no reason.
+	ReasonNotReady = "NotReady"
+	// ReasonUnknown is the reason of the summary condition when one of its dependencies is missing or unknown.
+	ReasonUnknown = "Unknown"
+)
+
+// ConditionsAccessor is implemented by typed objects that expose their status conditions directly, objects that do
+// not implement it are accessed through their status.conditions field.
+type ConditionsAccessor interface {
+	GetConditions() []metav1.Condition
+	SetConditions(conditions []metav1.Condition)
+}
+
+// GetConditions returns the status conditions of the object, it supports ConditionsAccessor implementations,
+// unstructured objects and typed objects that have a status.conditions field of metav1.Condition. An error is
+// returned for the other typed objects (eg: Deployment, Pod), whose conditions have their own types.
+func GetConditions(obj runtime.Object) ([]metav1.Condition, error) {
+	if accessor, ok := obj.(ConditionsAccessor); ok {
+		return accessor.GetConditions(), nil
+	}
+
+	content, err := conditionsContent(obj)
+	if err != nil {
+		return nil, err
+	}
+	items, found, err := unstructured.NestedSlice(content, "status", "conditions")
+	if err != nil || !found {
+		return nil, err
+	}
+	conditions := make([]metav1.Condition, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("status.conditions[%d] is %T, not an object", i, item)
+		}
+		condition := metav1.Condition{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(m, &condition); err != nil {
+			return nil, fmt.Errorf("status.conditions[%d]: %v", i, err)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// SetConditions replaces the status conditions of the object, the supported objects are the same as GetConditions.
+func SetConditions(obj runtime.Object, conditions []metav1.Condition) error {
+	if accessor, ok := obj.(ConditionsAccessor); ok {
+		accessor.SetConditions(conditions)
+		return nil
+	}
+
+	content, err := conditionsContent(obj)
+	if err != nil {
+		return err
+	}
+	items := make([]interface{}, 0, len(conditions))
+	for i := range conditions {
+		item, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&conditions[i])
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+	}
+	if err = unstructured.SetNestedSlice(content, items, "status", "conditions"); err != nil {
+		return err
+	}
+
+	if u, ok := obj.(runtime.Unstructured); ok {
+		u.SetUnstructuredContent(content)
+		return nil
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(content, obj)
+}
+
+// SetCondition adds or updates the condition of the object, returns true if the object's conditions are updated.
+// The LastTransitionTime is only changed when the status of the condition changes (or it is a new one), and the
+// ObservedGeneration is set to the generation of the object if it is not specified.
+func SetCondition(obj runtime.Object, condition metav1.Condition) (bool, error) {
+	conditions, err := GetConditions(obj)
+	if err != nil {
+		return false, err
+	}
+	if condition.ObservedGeneration == 0 {
+		if metaObj, ok := obj.(metav1.Object); ok {
+			condition.ObservedGeneration = metaObj.GetGeneration()
+		}
+	}
+	if !meta.SetStatusCondition(&conditions, condition) {
+		return false, nil
+	}
+	return true, SetConditions(obj, conditions)
+}
+
+// RemoveCondition removes the condition of the object, returns true if the object's conditions are updated.
+func RemoveCondition(obj runtime.Object, conditionType string) (bool, error) {
+	conditions, err := GetConditions(obj)
+	if err != nil {
+		return false, err
+	}
+	if !meta.RemoveStatusCondition(&conditions, conditionType) {
+		return false, nil
+	}
+	return true, SetConditions(obj, conditions)
+}
+
+// FindCondition returns the condition of the object with the given type, nil is returned if the condition does not
+// exist or the conditions cannot be read.
+func FindCondition(obj runtime.Object, conditionType string) *metav1.Condition {
+	conditions, err := GetConditions(obj)
+	if err != nil {
+		return nil
+	}
+	return meta.FindStatusCondition(conditions, conditionType)
+}
+
+// IsConditionTrue determines whether the condition of the object is present and true.
+func IsConditionTrue(obj runtime.Object, conditionType string) bool {
+	return conditionStatus(obj, conditionType) == metav1.ConditionTrue
+}
+
+// IsConditionFalse determines whether the condition of the object is present and false.
+func IsConditionFalse(obj runtime.Object, conditionType string) bool {
+	return conditionStatus(obj, conditionType) == metav1.ConditionFalse
+}
+
+// IsConditionUnknown determines whether the condition of the object is unknown, a missing condition is unknown.
+func IsConditionUnknown(obj runtime.Object, conditionType string) bool {
+	return conditionStatus(obj, conditionType) == metav1.ConditionUnknown
+}
+
+// SummarizeConditions computes a condition of the given type from its dependencies: it is false if any of them is
+// false, unknown if any of them is missing or unknown, and true otherwise. The reason and message of the first false
+// (or unknown) dependency are used for the summary.
+func SummarizeConditions(conditions []metav1.Condition, conditionType string, dependencies ...string) metav1.Condition {
+	summary := metav1.Condition{
+		Type:   conditionType,
+		Status: metav1.ConditionTrue,
+		Reason: ReasonAllReady,
+	}
+	var unknown []string
+	var unknownCondition *metav1.Condition
+	for _, dependency := range dependencies {
+		c := meta.FindStatusCondition(conditions, dependency)
+		switch {
+		case c == nil || c.Status == metav1.ConditionUnknown:
+			if unknownCondition == nil {
+				unknownCondition = c
+			}
+			unknown = append(unknown, dependency)
+		case c.Status == metav1.ConditionFalse:
+			summary.Status = metav1.ConditionFalse
+			summary.Reason = c.Reason
+			if summary.Reason == "" {
+				summary.Reason = ReasonNotReady
+			}
+			summary.Message = fmt.Sprintf("condition %s is false", c.Type)
+			if c.Message != "" {
+				summary.Message = fmt.Sprintf("%s: %s", c.Type, c.Message)
+			}
+			return summary
+		}
+	}
+
+	if len(unknown) > 0 {
+		summary.Status = metav1.ConditionUnknown
+		summary.Reason = ReasonUnknown
+		if unknownCondition != nil && unknownCondition.Reason != "" {
+			summary.Reason = unknownCondition.Reason
+		}
+		summary.Message = fmt.Sprintf("conditions %s are unknown", strings.Join(unknown, ", "))
+	}
+	return summary
+}
+
+// SetReadyCondition sets the Ready condition of the object summarised from its dependencies, returns true if the
+// object's conditions are updated.
+func SetReadyCondition(obj runtime.Object, dependencies ...string) (bool, error) {
+	conditions, err := GetConditions(obj)
+	if err != nil {
+		return false, err
+	}
+	return SetCondition(obj, SummarizeConditions(conditions, ReadyCondition, dependencies...))
+}
+
+func conditionStatus(obj runtime.Object, conditionType string) metav1.ConditionStatus {
+	c := FindCondition(obj, conditionType)
+	if c == nil {
+		return metav1.ConditionUnknown
+	}
+	return c.Status
+}
+
+var conditionsType = reflect.TypeOf([]metav1.Condition{})
+
+// conditionsContent returns the unstructured content of the object, an error is returned if the object is a typed
+// object whose Status.Conditions field is not a slice of metav1.Condition, since converting its conditions through
+// metav1.Condition loses their fields.
+func conditionsContent(obj runtime.Object) (map[string]interface{}, error) {
+	if _, ok := obj.(runtime.Unstructured); !ok {
+		value := reflect.Indirect(reflect.ValueOf(obj))
+		var conditions reflect.Value
+		if value.Kind() == reflect.Struct {
+			if status := value.FieldByName("Status"); status.Kind() == reflect.Struct {
+				conditions = status.FieldByName("Conditions")
+			}
+		}
+		if !conditions.IsValid() || conditions.Type() != conditionsType {
+			return nil, fmt.Errorf("%T does not have a Status.Conditions field of []metav1.Condition", obj)
+		}
+	}
+	return unstructuredContent(obj)
+}
+
+// unstructuredContent returns the content of the unstructured object directly, typed objects are converted.
+func unstructuredContent(obj runtime.Object) (map[string]interface{}, error) {
+	if u, ok := obj.(runtime.Unstructured); ok {
+		return u.UnstructuredContent(), nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
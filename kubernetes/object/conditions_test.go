@@ -0,0 +1,262 @@
+package object
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type conditionsObject struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	conditions []metav1.Condition
+}
+
+func (c *conditionsObject) GetConditions() []metav1.Condition {
+	return c.conditions
+}
+
+func (c *conditionsObject) SetConditions(conditions []metav1.Condition) {
+	c.conditions = conditions
+}
+
+func (c *conditionsObject) DeepCopyObject() runtime.Object {
+	out := *c
+	out.conditions = append([]metav1.Condition{}, c.conditions...)
+	return &out
+}
+
+func TestSetCondition(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  runtime.Object
+	}{
+		{
+			name: "accessor test",
+			obj: &conditionsObject{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			},
+		},
+		{
+			name: "typed test",
+			obj: &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			},
+		},
+		{
+			name: "unstructured test",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Test",
+					"metadata": map[string]interface{}{
+						"generation": int64(2),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := SetCondition(tt.obj, metav1.Condition{Type: "Synced", Status: metav1.ConditionTrue, Reason: "Done"})
+			if err != nil || !updated {
+				t.Fatalf("%s SetCondition() = %v, %v", tt.name, updated, err)
+			}
+			c := FindCondition(tt.obj, "Synced")
+			if c == nil {
+				t.Fatalf("FindCondition() = nil")
+			}
+			if c.ObservedGeneration != 2 || c.LastTransitionTime.IsZero() {
+				t.Errorf("SetCondition() = %+v, want observedGeneration 2 and lastTransitionTime set", c)
+			}
+			if !IsConditionTrue(tt.obj, "Synced") || IsConditionFalse(tt.obj, "Synced") || IsConditionUnknown(tt.obj, "Synced") {
+				t.Errorf("IsCondition*() returned wrong results for %+v", c)
+			}
+
+			// same status, the transition time must be preserved
+			transitionTime := c.LastTransitionTime
+			updated, err = SetCondition(tt.obj, metav1.Condition{
+				Type: "Synced", Status: metav1.ConditionTrue, Reason: "Done",
+				LastTransitionTime: metav1.NewTime(transitionTime.Add(time.Hour)),
+			})
+			if err != nil || updated {
+				t.Errorf("%s SetCondition() = %v, %v, want not updated", tt.name, updated, err)
+			}
+			if c = FindCondition(tt.obj, "Synced"); !c.LastTransitionTime.Equal(&transitionTime) {
+				t.Errorf("SetCondition() changed lastTransitionTime to %v", c.LastTransitionTime)
+			}
+
+			updated, err = RemoveCondition(tt.obj, "Synced")
+			if err != nil || !updated {
+				t.Errorf("%s RemoveCondition() = %v, %v", tt.name, updated, err)
+			}
+			if FindCondition(tt.obj, "Synced") != nil || !IsConditionUnknown(tt.obj, "Synced") {
+				t.Errorf("RemoveCondition() did not remove the condition")
+			}
+		})
+	}
+}
+
+func TestGetConditions(t *testing.T) {
+	tests := []struct {
+		name   string
+		obj    runtime.Object
+		isErr  bool
+		wanted int
+	}{
+		{
+			name: "normal test",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"status": map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"type":   "Ready",
+								"status": "True",
+							},
+						},
+					},
+				},
+			},
+			wanted: 1,
+		},
+		{
+			name:   "empty test",
+			obj:    &unstructured.Unstructured{},
+			wanted: 0,
+		},
+		{
+			name: "malformed test",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"status": map[string]interface{}{
+						"conditions": []interface{}{"Ready"},
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			name: "typed test",
+			obj: &policyv1.PodDisruptionBudget{
+				Status: policyv1.PodDisruptionBudgetStatus{
+					Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue}},
+				},
+			},
+			wanted: 1,
+		},
+		{
+			name: "unsupported conditions type test",
+			obj: &appsv1.Deployment{
+				Status: appsv1.DeploymentStatus{
+					Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing}},
+				},
+			},
+			isErr: true,
+		},
+		{
+			name:  "no conditions test",
+			obj:   &corev1.ConfigMap{},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := GetConditions(tt.obj)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s GetConditions() unexpected error: %v", tt.name, err)
+			}
+			if len(conditions) != tt.wanted {
+				t.Errorf("GetConditions() = %v, want %v", len(conditions), tt.wanted)
+			}
+		})
+	}
+}
+
+func TestSetConditionUnsupportedType(t *testing.T) {
+	updateTime := metav1.NewTime(time.Now().Truncate(time.Second))
+	dep := &appsv1.Deployment{
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:           appsv1.DeploymentProgressing,
+				Status:         corev1.ConditionTrue,
+				LastUpdateTime: updateTime,
+			}},
+		},
+	}
+	updated, err := SetCondition(dep, metav1.Condition{Type: "Custom", Status: metav1.ConditionTrue, Reason: "Done"})
+	if err == nil || updated {
+		t.Errorf("SetCondition() = %v, %v, want an error", updated, err)
+	}
+	if len(dep.Status.Conditions) != 1 || !dep.Status.Conditions[0].LastUpdateTime.Equal(&updateTime) {
+		t.Errorf("SetCondition() changed the conditions to %+v", dep.Status.Conditions)
+	}
+}
+
+func TestSummarizeConditions(t *testing.T) {
+	tests := []struct {
+		name         string
+		conditions   []metav1.Condition
+		dependencies []string
+		wantedStatus metav1.ConditionStatus
+		wantedReason string
+	}{
+		{
+			name: "true test",
+			conditions: []metav1.Condition{
+				{Type: "A", Status: metav1.ConditionTrue},
+				{Type: "B", Status: metav1.ConditionTrue},
+			},
+			dependencies: []string{"A", "B"},
+			wantedStatus: metav1.ConditionTrue,
+			wantedReason: ReasonAllReady,
+		},
+		{
+			name: "false test",
+			conditions: []metav1.Condition{
+				{Type: "A", Status: metav1.ConditionTrue},
+				{Type: "B", Status: metav1.ConditionFalse, Reason: "Failed"},
+			},
+			dependencies: []string{"A", "B", "C"},
+			wantedStatus: metav1.ConditionFalse,
+			wantedReason: "Failed",
+		},
+		{
+			name: "unknown test",
+			conditions: []metav1.Condition{
+				{Type: "A", Status: metav1.ConditionTrue},
+			},
+			dependencies: []string{"A", "B"},
+			wantedStatus: metav1.ConditionUnknown,
+			wantedReason: ReasonUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SummarizeConditions(tt.conditions, ReadyCondition, tt.dependencies...)
+			if got.Type != ReadyCondition || got.Status != tt.wantedStatus || got.Reason != tt.wantedReason {
+				t.Errorf("SummarizeConditions() = %+v, want status %v and reason %v", got, tt.wantedStatus, tt.wantedReason)
+			}
+		})
+	}
+}
+
+func TestSetReadyCondition(t *testing.T) {
+	obj := &conditionsObject{
+		conditions: []metav1.Condition{
+			{Type: "A", Status: metav1.ConditionTrue},
+		},
+	}
+	if updated, err := SetReadyCondition(obj, "A"); err != nil || !updated {
+		t.Fatalf("SetReadyCondition() = %v, %v", updated, err)
+	}
+	if !IsConditionTrue(obj, ReadyCondition) {
+		t.Errorf("SetReadyCondition() = %+v, want Ready true", obj.conditions)
+	}
+}
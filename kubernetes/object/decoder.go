@@ -0,0 +1,344 @@
+package object
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+)
+
+var yamlErrorLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// DecodeError is returned by ManifestDecoder when a document cannot be decoded.
+type DecodeError struct {
+	// Index is the 0-based index of the document in the source, empty documents are counted.
+	Index int
+	// Line is the 1-based line number in the source where the error occurred (or the document starts).
+	Line int
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("document %d (line %d): %v", e.Index, e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ManifestDecoder decodes the YAML or JSON manifests in a stream into unstructured objects one at a time, without
+// reading the whole stream into memory. YAML documents are separated by --- lines, empty and comment-only documents
+// are skipped. A stream that starts with { or [ is decoded as a JSON stream of objects, whose arrays are expanded
+// into their elements.
+//
+// eg:
+//
+//	decoder := NewManifestDecoder(file)
+//	for {
+//		obj, err := decoder.Next()
+//		if errors.Is(err, io.EOF) {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		// use obj
+//	}
+type ManifestDecoder struct {
+	reader *bufio.Reader
+	// isJSON is nil until the format of the stream is detected
+	isJSON *bool
+
+	// YAML state
+	line int
+
+	// JSON state
+	lines       *lineCounter
+	jsonDecoder *json.Decoder
+	pending     []jsonElement
+
+	index int
+}
+
+// NewManifestDecoder returns a new ManifestDecoder that reads from r.
+func NewManifestDecoder(r io.Reader) *ManifestDecoder {
+	return &ManifestDecoder{
+		reader: bufio.NewReader(r),
+		index:  -1,
+	}
+}
+
+// Next returns the next object in the stream, io.EOF is returned when there are no more objects. Decoding errors are
+// returned as *DecodeError.
+func (d *ManifestDecoder) Next() (*unstructured.Unstructured, error) {
+	for {
+		doc, err := d.nextDocument()
+		if err != nil {
+			return nil, err
+		}
+		content, err := doc.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+		if len(content) == 0 {
+			continue
+		}
+		return &unstructured.Unstructured{Object: content}, nil
+	}
+}
+
+// rawDocument is a non-empty YAML or JSON document of the stream.
+type rawDocument struct {
+	data  []byte
+	index int
+	// line is the 1-based line number in the stream where the document starts.
+	line int
+}
+
+// unmarshal decodes the document into an unstructured object content.
+func (doc *rawDocument) unmarshal() (map[string]interface{}, error) {
+	content, err := unmarshalObject(doc.data)
+	if err != nil {
+		line := doc.line
+		if m := yamlErrorLinePattern.FindStringSubmatch(err.Error()); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			line += n - 1
+		}
+		return nil, &DecodeError{Index: doc.index, Line: line, Err: err}
+	}
+	return content, nil
+}
+
+// nextDocument returns the next non-empty document in the stream without decoding it.
+func (d *ManifestDecoder) nextDocument() (*rawDocument, error) {
+	if d.isJSON == nil {
+		isJSON, err := d.detectJSON()
+		if err != nil {
+			return nil, err
+		}
+		d.isJSON = &isJSON
+		if isJSON {
+			d.lines = &lineCounter{reader: d.reader}
+			d.jsonDecoder = json.NewDecoder(d.lines)
+		}
+	}
+	if *d.isJSON {
+		return d.nextJSON()
+	}
+	return d.nextYAML()
+}
+
+// DecodeManifests decodes all the YAML or JSON manifests in the stream into unstructured objects.
+func DecodeManifests(r io.Reader) ([]*unstructured.Unstructured, error) {
+	decoder := NewManifestDecoder(r)
+	objs := make([]*unstructured.Unstructured, 0)
+	for {
+		obj, err := decoder.Next()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+}
+
+// detectJSON peeks the first non-whitespace character of the stream.
+func (d *ManifestDecoder) detectJSON() (bool, error) {
+	for n := 1; ; n++ {
+		data, err := d.reader.Peek(n)
+		if len(data) < n {
+			if errors.Is(err, io.EOF) {
+				return false, nil
+			}
+			return false, err
+		}
+		c := rune(data[n-1])
+		if unicode.IsSpace(c) {
+			continue
+		}
+		return c == '{' || c == '[', nil
+	}
+}
+
+func (d *ManifestDecoder) nextYAML() (*rawDocument, error) {
+	for {
+		doc, startLine, err := d.readYAMLDocument()
+		if err != nil {
+			return nil, err
+		}
+		d.index++
+		if !isEmptyYAMLDocument(doc) {
+			return &rawDocument{data: doc, index: d.index, line: startLine}, nil
+		}
+	}
+}
+
+// readYAMLDocument reads the lines of the next document, returns the document and its first line number.
+func (d *ManifestDecoder) readYAMLDocument() ([]byte, int, error) {
+	var doc bytes.Buffer
+	startLine := d.line + 1
+	for {
+		line, err := d.reader.ReadBytes('\n')
+		if len(line) > 0 {
+			d.line++
+			if isYAMLSeparator(line) {
+				return doc.Bytes(), startLine, nil
+			}
+			doc.Write(line)
+		}
+		if errors.Is(err, io.EOF) {
+			if doc.Len() == 0 {
+				return nil, 0, io.EOF
+			}
+			return doc.Bytes(), startLine, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+}
+
+func (d *ManifestDecoder) nextJSON() (*rawDocument, error) {
+	for {
+		if len(d.pending) > 0 {
+			elem := d.pending[0]
+			d.pending = d.pending[1:]
+			d.index++
+			return d.jsonDocument(elem.raw, elem.line)
+		}
+
+		var raw json.RawMessage
+		if err := d.jsonDecoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			offset := d.jsonDecoder.InputOffset()
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				// the offset of a syntax error is relative to the value being decoded
+				offset += syntaxErr.Offset - 1
+			}
+			return nil, &DecodeError{Index: d.index + 1, Line: d.lines.lineAt(offset), Err: err}
+		}
+		// the decoded value ends at the input offset
+		start := d.jsonDecoder.InputOffset() - int64(len(raw))
+		line := d.lines.lineAt(start)
+		if len(raw) > 0 && raw[0] == '[' {
+			elements, err := d.splitJSONArray(raw, start)
+			if err != nil {
+				return nil, &DecodeError{Index: d.index + 1, Line: line, Err: err}
+			}
+			d.pending = elements
+			continue
+		}
+		d.index++
+		return d.jsonDocument(raw, line)
+	}
+}
+
+// jsonElement is an element of a JSON array in the stream.
+type jsonElement struct {
+	raw json.RawMessage
+	// line is the 1-based line number in the stream where the element starts.
+	line int
+}
+
+// splitJSONArray returns the elements of the JSON array that starts at the offset of the stream.
+func (d *ManifestDecoder) splitJSONArray(raw json.RawMessage, offset int64) ([]jsonElement, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	elements := make([]jsonElement, 0)
+	for decoder.More() {
+		var elem json.RawMessage
+		if err := decoder.Decode(&elem); err != nil {
+			return nil, err
+		}
+		start := offset + decoder.InputOffset() - int64(len(elem))
+		elements = append(elements, jsonElement{raw: elem, line: d.lines.lineAt(start)})
+	}
+	return elements, nil
+}
+
+func (d *ManifestDecoder) jsonDocument(raw json.RawMessage, line int) (*rawDocument, error) {
+	if len(raw) == 0 || raw[0] != '{' {
+		return nil, &DecodeError{Index: d.index, Line: line, Err: fmt.Errorf("expected a JSON object, got %s", raw)}
+	}
+	return &rawDocument{data: raw, index: d.index, line: line}, nil
+}
+
+// unmarshalObject decodes the YAML or JSON object, the integers are decoded as int64 like the other unstructured
+// objects, instead of float64.
+func unmarshalObject(data []byte) (map[string]interface{}, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	content := make(map[string]interface{})
+	if err = utiljson.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// isYAMLSeparator determines whether the line is a document separator (eg: --- or --- # comment).
+func isYAMLSeparator(line []byte) bool {
+	line = bytes.TrimRight(line, " \t\r\n")
+	if !bytes.HasPrefix(line, []byte("---")) {
+		return false
+	}
+	rest := line[3:]
+	return len(rest) == 0 || rest[0] == ' ' || rest[0] == '\t'
+}
+
+// isEmptyYAMLDocument determines whether the document only contains blank lines and comments.
+func isEmptyYAMLDocument(doc []byte) bool {
+	for _, line := range bytes.Split(doc, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 && line[0] != '#' {
+			return false
+		}
+	}
+	return true
+}
+
+// lineCounter records the offsets of the newlines read through it, so that stream offsets can be converted to line
+// numbers.
+type lineCounter struct {
+	reader   io.Reader
+	offset   int64
+	newlines []int64
+}
+
+func (c *lineCounter) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	for i := 0; i < n; i++ {
+		if p[i] == '\n' {
+			c.newlines = append(c.newlines, c.offset+int64(i))
+		}
+	}
+	c.offset += int64(n)
+	return n, err
+}
+
+// lineAt returns the 1-based line number of the offset.
+func (c *lineCounter) lineAt(offset int64) int {
+	return sort.Search(len(c.newlines), func(i int) bool {
+		return c.newlines[i] >= offset
+	}) + 1
+}
@@ -0,0 +1,143 @@
+package object
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeManifests(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		isErr  bool
+		line   int
+		index  int
+		wanted []string
+	}{
+		{
+			name: "yaml documents test",
+			input: `# leading comment
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns1
+---
+# only a comment
+---
+
+--- # separator with comment
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+`,
+			wanted: []string{"Namespace/ns1", "ConfigMap/cm1"},
+		},
+		{
+			name:   "empty input test",
+			input:  "\n\n",
+			wanted: []string{},
+		},
+		{
+			name:   "json stream test",
+			input:  `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "ns1"}}` + "\n" + `{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "s1"}}`,
+			wanted: []string{"Namespace/ns1", "Secret/s1"},
+		},
+		{
+			name: "json array test",
+			input: `[
+  {"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "ns1"}},
+  {"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "s1"}}
+]`,
+			wanted: []string{"Namespace/ns1", "Secret/s1"},
+		},
+		{
+			name: "invalid yaml test",
+			input: `apiVersion: v1
+kind: Namespace
+---
+apiVersion: v1
+kind: ConfigMap
+data:
+  key: [
+`,
+			isErr: true,
+			index: 1,
+			line:  7,
+		},
+		{
+			name: "invalid json test",
+			input: `{"apiVersion": "v1", "kind": "Namespace"}
+
+{"apiVersion": "v1", "kind": }`,
+			isErr: true,
+			index: 1,
+			line:  3,
+		},
+		{
+			name: "json non-object test",
+			input: `[{"apiVersion": "v1", "kind": "Namespace"},
+"value"]`,
+			isErr: true,
+			index: 1,
+			line:  2,
+		},
+		{
+			name: "json array element line test",
+			input: `[
+  {"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "ns1"}},
+  {"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "s1"}},
+
+  3
+]`,
+			isErr: true,
+			index: 2,
+			line:  5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs, err := DecodeManifests(strings.NewReader(tt.input))
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s DecodeManifests() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				var decodeErr *DecodeError
+				if !errors.As(err, &decodeErr) {
+					t.Errorf("DecodeManifests() error = %v, want DecodeError", err)
+					return
+				}
+				if decodeErr.Index != tt.index || decodeErr.Line != tt.line {
+					t.Errorf("DecodeManifests() error at document %d line %d, want document %d line %d",
+						decodeErr.Index, decodeErr.Line, tt.index, tt.line)
+				}
+				return
+			}
+			got := make([]string, 0, len(objs))
+			for _, obj := range objs {
+				got = append(got, obj.GetKind()+"/"+obj.GetName())
+			}
+			if !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("DecodeManifests() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestDecodeManifestsIntegers(t *testing.T) {
+	for _, input := range []string{
+		"apiVersion: apps/v1\nkind: Deployment\nspec:\n  replicas: 3\n",
+		`{"apiVersion": "apps/v1", "kind": "Deployment", "spec": {"replicas": 3}}`,
+	} {
+		objs, err := DecodeManifests(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("DecodeManifests() unexpected error: %v", err)
+		}
+		if replicas := objs[0].Object["spec"].(map[string]interface{})["replicas"]; replicas != int64(3) {
+			t.Errorf("DecodeManifests() replicas = %#v, want int64(3)", replicas)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+package object
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// YAMLEditor edits the YAML documents of a manifest file through their node trees, so that the comments, key order
+// and document separators are preserved, unlike decoding and encoding the documents as maps. The fields are
+// addressed by nested paths (eg: spec.template.spec.containers.0.image), the numeric segments are sequence indices.
+// The unedited documents are written back as they are and the replaced scalar values are spliced into the original
+// text, so the formatting of the file (eg: the sequence indentation) is kept. The documents whose structure is
+// changed (added or removed fields, non-scalar values, etc.) are re-encoded with 2-space indentation.
+//
+// eg:
+//
+//	editor, err := NewYAMLEditor(data)
+//	if err != nil {
+//		return err
+//	}
+//	index := editor.FindDocument("Deployment", "web")
+//	if err = editor.Set(index, "spec.template.spec.containers.0.image", "web:v2"); err != nil {
+//		return err
+//	}
+//	data, err = editor.Bytes()
+type YAMLEditor struct {
+	// leading is the blank and comment lines before the first document
+	leading []byte
+	docs    []*yamlDocument
+}
+
+// yamlDocument is a document of the file along with its original content.
+type yamlDocument struct {
+	node *yamlv3.Node
+	// separator is the --- line before the document, it is empty for a first document without separator.
+	separator []byte
+	content   []byte
+	// edits are the spans in the original content of the replaced scalar nodes.
+	edits map[*yamlv3.Node]scalarSpan
+	// reencode is true if the structure of the document is changed, so the edits cannot be spliced into the content.
+	reencode bool
+}
+
+// scalarSpan is the byte range [start, end) of a scalar value in the document content.
+type scalarSpan struct {
+	start, end int
+}
+
+// NewYAMLEditor parses the YAML documents of the content.
+func NewYAMLEditor(data []byte) (*YAMLEditor, error) {
+	docs := []*yamlDocument{{}}
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if isYAMLSeparator(line) {
+			docs = append(docs, &yamlDocument{separator: line})
+			continue
+		}
+		last := docs[len(docs)-1]
+		last.content = append(last.content, line...)
+	}
+
+	editor := &YAMLEditor{}
+	if first := docs[0]; isEmptyYAMLDocument(first.content) {
+		// the comments before the first separator are not a document
+		editor.leading = first.content
+		docs = docs[1:]
+	}
+	for i, doc := range docs {
+		doc.node = &yamlv3.Node{}
+		if err := yamlv3.Unmarshal(doc.content, doc.node); err != nil {
+			return nil, fmt.Errorf("document %d: %v", i, err)
+		}
+		doc.edits = make(map[*yamlv3.Node]scalarSpan)
+	}
+	editor.docs = docs
+	return editor, nil
+}
+
+// Len returns the number of documents.
+func (e *YAMLEditor) Len() int {
+	return len(e.docs)
+}
+
+// FindDocument returns the index of the first document of the object with the kind and name, -1 is returned if
+// there is no such document.
+func (e *YAMLEditor) FindDocument(kind, name string) int {
+	for i := range e.docs {
+		docKind, _, _ := e.Get(i, "kind")
+		docName, _, _ := e.Get(i, "metadata.name")
+		if docKind == kind && docName == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get returns the value of the nested field of the document, false is returned if the field does not exist.
+func (e *YAMLEditor) Get(index int, path string) (interface{}, bool, error) {
+	node, err := e.root(index)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, key := range splitYAMLPath(path) {
+		node, _ = childNode(node, key)
+		if node == nil {
+			return nil, false, nil
+		}
+	}
+	var value interface{}
+	if err = node.Decode(&value); err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set sets the value of the nested field of the document, the missing maps of the path are created. The comments of
+// a replaced node are kept, and so is the quoting style of a replaced string.
+func (e *YAMLEditor) Set(index int, path string, value interface{}) error {
+	node, err := e.root(index)
+	if err != nil {
+		return err
+	}
+	doc := e.docs[index]
+	newNode := &yamlv3.Node{}
+	if err = newNode.Encode(value); err != nil {
+		return err
+	}
+
+	keys := splitYAMLPath(path)
+	if len(keys) == 0 {
+		return errors.New("empty path")
+	}
+	for i, key := range keys {
+		isLast := i == len(keys)-1
+		child, err := childNode(node, key)
+		if err != nil {
+			return fmt.Errorf("%s: %v", strings.Join(keys[:i+1], "."), err)
+		}
+		if child != nil && !isLast {
+			node = child
+			continue
+		}
+		if child != nil {
+			doc.recordEdit(node, child, newNode)
+			replaceNode(child, newNode)
+			return nil
+		}
+
+		next := newNode
+		if !isLast {
+			next = &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+		}
+		if err = addChildNode(node, key, next); err != nil {
+			return fmt.Errorf("%s: %v", strings.Join(keys[:i+1], "."), err)
+		}
+		doc.reencode = true
+		node = next
+	}
+	return nil
+}
+
+// Remove removes the nested field of the document, returns true if the field is removed.
+func (e *YAMLEditor) Remove(index int, path string) (bool, error) {
+	node, err := e.root(index)
+	if err != nil {
+		return false, err
+	}
+	keys := splitYAMLPath(path)
+	if len(keys) == 0 {
+		return false, errors.New("empty path")
+	}
+	for _, key := range keys[:len(keys)-1] {
+		node, _ = childNode(node, key)
+		if node == nil {
+			return false, nil
+		}
+	}
+
+	key := keys[len(keys)-1]
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				node.Content = append(node.Content[:i], node.Content[i+2:]...)
+				e.docs[index].reencode = true
+				return true, nil
+			}
+		}
+	case yamlv3.SequenceNode:
+		i, err := strconv.Atoi(key)
+		if err == nil && i >= 0 && i < len(node.Content) {
+			node.Content = append(node.Content[:i], node.Content[i+1:]...)
+			e.docs[index].reencode = true
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Bytes returns the edited YAML content.
+func (e *YAMLEditor) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(e.leading)
+	for _, doc := range e.docs {
+		buf.Write(doc.separator)
+		content, err := doc.bytes()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(content)
+	}
+	return buf.Bytes(), nil
+}
+
+// bytes returns the edited content of the document, the original content is returned if it is not edited.
+func (doc *yamlDocument) bytes() ([]byte, error) {
+	if !doc.reencode {
+		if content, ok := doc.splice(); ok {
+			return content, nil
+		}
+	}
+	var buf bytes.Buffer
+	encoder := yamlv3.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc.node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// splice replaces the spans of the edited scalars in the original content with their new values, false is returned
+// if a new value cannot be written inline.
+func (doc *yamlDocument) splice() ([]byte, bool) {
+	type replacement struct {
+		scalarSpan
+		text string
+	}
+	replacements := make([]replacement, 0, len(doc.edits))
+	for node, span := range doc.edits {
+		text, ok := inlineScalar(node)
+		if !ok {
+			return nil, false
+		}
+		replacements = append(replacements, replacement{scalarSpan: span, text: text})
+	}
+	sort.Slice(replacements, func(i, j int) bool {
+		return replacements[i].start < replacements[j].start
+	})
+
+	result := make([]byte, 0, len(doc.content))
+	offset := 0
+	for _, r := range replacements {
+		result = append(result, doc.content[offset:r.start]...)
+		result = append(result, r.text...)
+		offset = r.end
+	}
+	return append(result, doc.content[offset:]...), true
+}
+
+// recordEdit records the original span of the scalar node before it is replaced by the value, the document is
+// re-encoded if the replacement cannot be spliced into the content.
+func (doc *yamlDocument) recordEdit(parent, node, value *yamlv3.Node) {
+	if _, ok := doc.edits[node]; ok || doc.reencode {
+		return
+	}
+	if parent.Kind == yamlv3.AliasNode {
+		parent = parent.Alias
+	}
+	// the values in flow collections (eg: [a, b]) may need different quoting
+	if value.Kind == yamlv3.ScalarNode && parent.Style&yamlv3.FlowStyle == 0 {
+		if span, ok := findScalarSpan(doc.content, node); ok {
+			doc.edits[node] = span
+			return
+		}
+	}
+	doc.reencode = true
+}
+
+// findScalarSpan returns the span of the single-line plain or quoted scalar node in the content.
+func findScalarSpan(content []byte, node *yamlv3.Node) (scalarSpan, bool) {
+	if node.Kind != yamlv3.ScalarNode || node.Anchor != "" || node.Line == 0 ||
+		node.Style&^(yamlv3.SingleQuotedStyle|yamlv3.DoubleQuotedStyle) != 0 {
+		return scalarSpan{}, false
+	}
+	lineStart := 0
+	for i := 1; i < node.Line; i++ {
+		n := bytes.IndexByte(content[lineStart:], '\n')
+		if n < 0 {
+			return scalarSpan{}, false
+		}
+		lineStart += n + 1
+	}
+	line := content[lineStart:]
+	if n := bytes.IndexByte(line, '\n'); n >= 0 {
+		line = line[:n]
+	}
+	// the column counts characters, not bytes
+	offset := 0
+	for column := 1; column < node.Column; column++ {
+		if offset >= len(line) {
+			return scalarSpan{}, false
+		}
+		_, size := utf8.DecodeRune(line[offset:])
+		offset += size
+	}
+
+	rest := line[offset:]
+	length := 0
+	switch node.Style {
+	case yamlv3.DoubleQuotedStyle:
+		length = quotedLength(rest, '"')
+	case yamlv3.SingleQuotedStyle:
+		length = quotedLength(rest, '\'')
+	default:
+		if node.Value != "" && bytes.HasPrefix(rest, []byte(node.Value)) {
+			length = len(node.Value)
+		}
+	}
+	if length == 0 {
+		return scalarSpan{}, false
+	}
+	start := lineStart + offset
+	return scalarSpan{start: start, end: start + length}, true
+}
+
+// quotedLength returns the length of the quoted string at the beginning of the text, 0 is returned if the string is
+// not closed.
+func quotedLength(text []byte, quote byte) int {
+	if len(text) == 0 || text[0] != quote {
+		return 0
+	}
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case quote == '\'' && text[i] == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// inlineScalar encodes the scalar node without its comments, false is returned if it does not fit in one line.
+func inlineScalar(node *yamlv3.Node) (string, bool) {
+	if node.Kind != yamlv3.ScalarNode {
+		return "", false
+	}
+	scalar := *node
+	scalar.HeadComment, scalar.LineComment, scalar.FootComment = "", "", ""
+	data, err := yamlv3.Marshal(&scalar)
+	if err != nil {
+		return "", false
+	}
+	text := strings.TrimSuffix(string(data), "\n")
+	if strings.Contains(text, "\n") {
+		return "", false
+	}
+	return text, true
+}
+
+// root returns the root node of the document, an empty document gets an empty map.
+func (e *YAMLEditor) root(index int) (*yamlv3.Node, error) {
+	if index < 0 || index >= len(e.docs) {
+		return nil, fmt.Errorf("document index %d out of range [0, %d)", index, len(e.docs))
+	}
+	doc := e.docs[index].node
+	if len(doc.Content) == 0 {
+		doc.Kind = yamlv3.DocumentNode
+		doc.Content = []*yamlv3.Node{{Kind: yamlv3.MappingNode, Tag: "!!map"}}
+	}
+	return doc.Content[0], nil
+}
+
+func splitYAMLPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// childNode returns the value node of the key of a map or the element of a sequence, nil is returned if it does not
+// exist. An error is returned if the node is not a map or sequence.
+func childNode(node *yamlv3.Node, key string) (*yamlv3.Node, error) {
+	if node.Kind == yamlv3.AliasNode {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1], nil
+			}
+		}
+		return nil, nil
+	case yamlv3.SequenceNode:
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sequence index %q", key)
+		}
+		if i < 0 || i >= len(node.Content) {
+			return nil, nil
+		}
+		return node.Content[i], nil
+	default:
+		return nil, fmt.Errorf("cannot access field %q of a scalar value", key)
+	}
+}
+
+// addChildNode adds the value to the map, or appends it to the sequence if the index is the length of the sequence.
+func addChildNode(node *yamlv3.Node, key string, value *yamlv3.Node) error {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		node.Content = append(node.Content, &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: key}, value)
+		return nil
+	case yamlv3.SequenceNode:
+		if i, err := strconv.Atoi(key); err != nil || i != len(node.Content) {
+			return fmt.Errorf("sequence index %s out of range [0, %d]", key, len(node.Content))
+		}
+		node.Content = append(node.Content, value)
+		return nil
+	default:
+		return fmt.Errorf("cannot set field %q of a scalar value", key)
+	}
+}
+
+// replaceNode replaces the content of the node in place, keeping its comments and string style.
+func replaceNode(node, value *yamlv3.Node) {
+	style := node.Style
+	keepStyle := node.Kind == yamlv3.ScalarNode && value.Kind == yamlv3.ScalarNode && node.Tag == value.Tag &&
+		!strings.Contains(value.Value, "\n")
+	headComment, lineComment, footComment := node.HeadComment, node.LineComment, node.FootComment
+
+	*node = *value
+	if keepStyle {
+		node.Style = style
+	}
+	node.HeadComment, node.LineComment, node.FootComment = headComment, lineComment, footComment
+}
@@ -0,0 +1,277 @@
+package object
+
+import (
+	"strings"
+	"testing"
+)
+
+const editorManifest = `---
+# the web namespace
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web # the name
+  namespace: web
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+        # the main container
+        - name: web
+          image: "web:v1" # bumped by CI
+`
+
+func TestYAMLEditor(t *testing.T) {
+	tests := []struct {
+		name   string
+		edit   func(e *YAMLEditor) error
+		isErr  bool
+		wanted string
+	}{
+		{
+			name: "set scalar test",
+			edit: func(e *YAMLEditor) error {
+				return e.Set(e.FindDocument("Deployment", "web"), "spec.template.spec.containers.0.image", "web:v2")
+			},
+			wanted: `---
+# the web namespace
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web # the name
+  namespace: web
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+        # the main container
+        - name: web
+          image: "web:v2" # bumped by CI
+`,
+		},
+		{
+			name: "set new fields test",
+			edit: func(e *YAMLEditor) error {
+				if err := e.Set(1, "metadata.labels.app", "web"); err != nil {
+					return err
+				}
+				if err := e.Set(1, "spec.replicas", 3); err != nil {
+					return err
+				}
+				return e.Set(1, "spec.template.spec.containers.1", map[string]interface{}{"name": "sidecar"})
+			},
+			wanted: `---
+# the web namespace
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web # the name
+  namespace: web
+  labels:
+    app: web
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+        # the main container
+        - name: web
+          image: "web:v1" # bumped by CI
+        - name: sidecar
+`,
+		},
+		{
+			name: "remove test",
+			edit: func(e *YAMLEditor) error {
+				_, err := e.Remove(1, "metadata.namespace")
+				return err
+			},
+			wanted: `---
+# the web namespace
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web # the name
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+        # the main container
+        - name: web
+          image: "web:v1" # bumped by CI
+`,
+		},
+		{
+			name: "index out of range test",
+			edit: func(e *YAMLEditor) error {
+				return e.Set(1, "spec.template.spec.containers.5.name", "x")
+			},
+			isErr: true,
+		},
+		{
+			name: "scalar field test",
+			edit: func(e *YAMLEditor) error {
+				return e.Set(1, "spec.replicas.value", 1)
+			},
+			isErr: true,
+		},
+		{
+			name: "document out of range test",
+			edit: func(e *YAMLEditor) error {
+				return e.Set(2, "kind", "Secret")
+			},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editor, err := NewYAMLEditor([]byte(editorManifest))
+			if err != nil {
+				t.Fatalf("NewYAMLEditor() unexpected error: %v", err)
+			}
+			err = tt.edit(editor)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s YAMLEditor edit unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			data, err := editor.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes() unexpected error: %v", err)
+			}
+			if string(data) != tt.wanted {
+				t.Errorf("Bytes() = %s, want %s", data, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestYAMLEditorGet(t *testing.T) {
+	editor, err := NewYAMLEditor([]byte(editorManifest))
+	if err != nil {
+		t.Fatalf("NewYAMLEditor() unexpected error: %v", err)
+	}
+	if editor.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", editor.Len())
+	}
+	value, found, err := editor.Get(1, "spec.template.spec.containers.0.image")
+	if err != nil || !found || value != "web:v1" {
+		t.Errorf("Get() = %v, %v, %v, want web:v1", value, found, err)
+	}
+	value, found, err = editor.Get(1, "spec.replicas")
+	if err != nil || !found || value != 1 {
+		t.Errorf("Get() = %v, %v, %v, want 1", value, found, err)
+	}
+	if _, found, _ = editor.Get(1, "spec.missing"); found {
+		t.Errorf("Get() found a missing field")
+	}
+	if index := editor.FindDocument("Secret", "web"); index != -1 {
+		t.Errorf("FindDocument() = %d, want -1", index)
+	}
+}
+
+const kubectlManifest = `# leading comment
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cfg
+data:
+  greeting: 'it''s héllo' # comment
+---
+# only a comment
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:v1
+        args: ["--port", "80"]
+        env:
+        - name: MODE
+          value: "prod"
+`
+
+func TestYAMLEditorFormatting(t *testing.T) {
+	tests := []struct {
+		name   string
+		edit   func(e *YAMLEditor) error
+		wanted string
+	}{
+		{
+			name: "no-op test",
+			edit: func(e *YAMLEditor) error {
+				if _, _, err := e.Get(1, "kind"); err != nil {
+					return err
+				}
+				_, _, err := e.Get(2, "spec.template.spec.containers.0.image")
+				return err
+			},
+			wanted: kubectlManifest,
+		},
+		{
+			name: "set scalars test",
+			edit: func(e *YAMLEditor) error {
+				if err := e.Set(0, "data.greeting", "bonjour"); err != nil {
+					return err
+				}
+				if err := e.Set(2, "spec.template.spec.containers.0.image", "web:v2"); err != nil {
+					return err
+				}
+				return e.Set(2, "spec.template.spec.containers.0.env.0.value", "a \"b\"")
+			},
+			wanted: strings.NewReplacer(
+				"'it''s héllo'", "'bonjour'",
+				"web:v1", "web:v2",
+				`"prod"`, `"a \"b\""`,
+			).Replace(kubectlManifest),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editor, err := NewYAMLEditor([]byte(kubectlManifest))
+			if err != nil {
+				t.Fatalf("NewYAMLEditor() unexpected error: %v", err)
+			}
+			if err = tt.edit(editor); err != nil {
+				t.Fatalf("%s YAMLEditor edit unexpected error: %v", tt.name, err)
+			}
+			data, err := editor.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes() unexpected error: %v", err)
+			}
+			if string(data) != tt.wanted {
+				t.Errorf("Bytes() = %s, want %s", data, tt.wanted)
+			}
+		})
+	}
+}
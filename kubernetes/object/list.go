@@ -0,0 +1,67 @@
+package object
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// IsListKind determines whether the object is a list (eg: v1/List or PodList) whose items are the real objects.
+func IsListKind(obj *unstructured.Unstructured) bool {
+	return strings.HasSuffix(obj.GetKind(), "List") && obj.IsList()
+}
+
+// ExpandLists flattens the list objects into their items recursively, the order of the objects is preserved.
+// The items of typed lists (eg: PodList) that have no apiVersion and kind inherit them from the list.
+func ExpandLists(objs ...*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	result := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		expanded, err := expandList(obj)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, expanded...)
+	}
+	return result, nil
+}
+
+// LoadManifests decodes all the YAML or JSON manifests in the stream into unstructured objects, the list objects
+// (eg: the output of kubectl get -o yaml) are expanded into their items.
+func LoadManifests(r io.Reader) ([]*unstructured.Unstructured, error) {
+	objs, err := DecodeManifests(r)
+	if err != nil {
+		return nil, err
+	}
+	return ExpandLists(objs...)
+}
+
+func expandList(obj *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	if !IsListKind(obj) {
+		return []*unstructured.Unstructured{obj}, nil
+	}
+
+	items, _, err := unstructured.NestedSlice(obj.Object, "items")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*unstructured.Unstructured, 0, len(items))
+	for i, item := range items {
+		content, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s %s items[%d] is %T, not an object", obj.GetKind(), obj.GetName(), i, item)
+		}
+		child := &unstructured.Unstructured{Object: content}
+		if child.GetKind() == "" && obj.GetKind() != "List" {
+			child.SetAPIVersion(obj.GetAPIVersion())
+			child.SetKind(strings.TrimSuffix(obj.GetKind(), "List"))
+		}
+		expanded, err := expandList(child)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, expanded...)
+	}
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package object
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadManifests(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		isErr  bool
+		wanted []string
+	}{
+		{
+			name: "list test",
+			input: `apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns1
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: cm1
+- apiVersion: v1
+  kind: List
+  items:
+  - apiVersion: v1
+    kind: Secret
+    metadata:
+      name: s1
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: cm2
+`,
+			wanted: []string{"v1/Namespace/ns1", "v1/ConfigMap/cm1", "v1/Secret/s1", "v1/ConfigMap/cm2"},
+		},
+		{
+			name: "typed list test",
+			input: `apiVersion: apps/v1
+kind: DeploymentList
+items:
+- metadata:
+    name: d1
+`,
+			wanted: []string{"apps/v1/Deployment/d1"},
+		},
+		{
+			name: "empty list test",
+			input: `apiVersion: v1
+kind: List
+items: []
+`,
+			wanted: []string{},
+		},
+		{
+			name: "invalid item test",
+			input: `apiVersion: v1
+kind: List
+items:
+- foo
+`,
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs, err := LoadManifests(strings.NewReader(tt.input))
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s LoadManifests() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			got := make([]string, 0, len(objs))
+			for _, obj := range objs {
+				got = append(got, obj.GetAPIVersion()+"/"+obj.GetKind()+"/"+obj.GetName())
+			}
+			if !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("LoadManifests() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
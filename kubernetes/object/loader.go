@@ -0,0 +1,191 @@
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Manifest is an object loaded from a manifest file.
+type Manifest struct {
+	// Source is the path of the file the object is loaded from.
+	Source string
+	// Index is the index of the object in the file.
+	Index  int
+	Object *unstructured.Unstructured
+}
+
+// LoadOptions defines options needed to load manifests from files.
+type LoadOptions struct {
+	extensions       []string
+	recursive        bool
+	sourceAnnotation string
+}
+
+// WithExtensions sets the extensions of the files loaded from directories, the files matched by a pattern directly
+// are always loaded.
+// The default value is .yaml, .yml and .json.
+func WithExtensions(extensions ...string) func(*LoadOptions) {
+	return func(o *LoadOptions) {
+		o.extensions = extensions
+	}
+}
+
+// WithRecursive sets whether to load the files in the subdirectories of the directories.
+// The default value is true.
+func WithRecursive(recursive bool) func(*LoadOptions) {
+	return func(o *LoadOptions) {
+		o.recursive = recursive
+	}
+}
+
+// WithSourceAnnotation sets the annotation that records the source file on the loaded objects, the annotation is not
+// set if the key is empty.
+// The default value is empty.
+func WithSourceAnnotation(key string) func(*LoadOptions) {
+	return func(o *LoadOptions) {
+		o.sourceAnnotation = key
+	}
+}
+
+// LoadManifestsFromPaths loads the manifests from the files and directory trees matched by the glob patterns
+// (eg: manifests/*.yaml or deploy). The files are loaded in lexical order, hidden files and directories are skipped
+// and the list objects are expanded into their items.
+func LoadManifestsFromPaths(patterns []string, options ...func(*LoadOptions)) ([]Manifest, error) {
+	return loadManifests(loader{
+		glob:     filepath.Glob,
+		walkDir:  filepath.WalkDir,
+		readFile: os.ReadFile,
+		ext:      filepath.Ext,
+		base:     filepath.Base,
+	}, patterns, options...)
+}
+
+// LoadManifestsFromFS is the same as LoadManifestsFromPaths but loads the manifests from the file system, so that
+// the manifests embedded by embed.FS can be loaded.
+//
+// eg:
+//
+//	//go:embed manifests
+//	var manifests embed.FS
+//
+//	manifests, err := LoadManifestsFromFS(manifests, []string{"manifests"})
+func LoadManifestsFromFS(fsys fs.FS, patterns []string, options ...func(*LoadOptions)) ([]Manifest, error) {
+	return loadManifests(loader{
+		glob: func(pattern string) ([]string, error) {
+			return fs.Glob(fsys, pattern)
+		},
+		walkDir: func(root string, fn fs.WalkDirFunc) error {
+			return fs.WalkDir(fsys, root, fn)
+		},
+		readFile: func(name string) ([]byte, error) {
+			return fs.ReadFile(fsys, name)
+		},
+		ext:  path.Ext,
+		base: path.Base,
+	}, patterns, options...)
+}
+
+// Objects returns the objects of the manifests.
+func Objects(manifests []Manifest) []*unstructured.Unstructured {
+	objs := make([]*unstructured.Unstructured, 0, len(manifests))
+	for _, m := range manifests {
+		objs = append(objs, m.Object)
+	}
+	return objs
+}
+
+type loader struct {
+	glob     func(pattern string) ([]string, error)
+	walkDir  func(root string, fn fs.WalkDirFunc) error
+	readFile func(name string) ([]byte, error)
+	ext      func(name string) string
+	base     func(name string) string
+}
+
+func loadManifests(l loader, patterns []string, options ...func(*LoadOptions)) ([]Manifest, error) {
+	opts := &LoadOptions{
+		extensions: []string{".yaml", ".yml", ".json"},
+		recursive:  true,
+	}
+	for _, f := range options {
+		f(opts)
+	}
+	// the extensions of the files are compared case-insensitively
+	extensions := make([]string, 0, len(opts.extensions))
+	for _, ext := range opts.extensions {
+		extensions = append(extensions, strings.ToLower(ext))
+	}
+
+	files := sets.New[string]()
+	for _, pattern := range patterns {
+		matches, err := l.glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match pattern %q", pattern)
+		}
+		for _, match := range matches {
+			if isHidden(l.base(match)) && !isHidden(l.base(pattern)) {
+				// like shells, wildcards do not match hidden files
+				continue
+			}
+			err = l.walkDir(match, func(name string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if name == match {
+					if !d.IsDir() {
+						files.Insert(name)
+					}
+					return nil
+				}
+				if isHidden(d.Name()) || d.IsDir() && !opts.recursive {
+					if d.IsDir() {
+						return fs.SkipDir
+					}
+					return nil
+				}
+				if !d.IsDir() && slices.Contains(extensions, strings.ToLower(l.ext(name))) {
+					files.Insert(name)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	manifests := make([]Manifest, 0)
+	for _, file := range sets.List(files) {
+		data, err := l.readFile(file)
+		if err != nil {
+			return nil, err
+		}
+		objs, err := LoadManifests(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		for i, obj := range objs {
+			if opts.sourceAnnotation != "" {
+				AddAnnotation(obj, opts.sourceAnnotation, file)
+			}
+			manifests = append(manifests, Manifest{Source: file, Index: i, Object: obj})
+		}
+	}
+	return manifests, nil
+}
+
+func isHidden(name string) bool {
+	return strings.HasPrefix(name, ".") && name != "." && name != ".."
+}
@@ -0,0 +1,122 @@
+package object
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func newManifestFS() fstest.MapFS {
+	return fstest.MapFS{
+		"deploy/b.yaml":        {Data: []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: b\n")},
+		"deploy/a.yml":         {Data: []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: a1\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: a2\n")},
+		"deploy/README.md":     {Data: []byte("# manifests")},
+		"deploy/.hidden.yaml":  {Data: []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: hidden\n")},
+		"deploy/sub/c.json":    {Data: []byte(`{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "c"}}`)},
+		"deploy/sub/list.yaml": {Data: []byte("apiVersion: v1\nkind: List\nitems:\n- apiVersion: v1\n  kind: Namespace\n  metadata:\n    name: d\n")},
+		"broken/bad.yaml":      {Data: []byte("apiVersion: v1\nkind: [\n")},
+	}
+}
+
+func manifestSummary(manifests []Manifest) []string {
+	result := make([]string, 0, len(manifests))
+	for _, m := range manifests {
+		result = append(result, m.Source+":"+m.Object.GetName())
+	}
+	return result
+}
+
+func TestLoadManifestsFromFS(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		options  []func(*LoadOptions)
+		isErr    bool
+		wanted   []string
+	}{
+		{
+			name:     "directory test",
+			patterns: []string{"deploy"},
+			wanted: []string{
+				"deploy/a.yml:a1", "deploy/a.yml:a2", "deploy/b.yaml:b", "deploy/sub/c.json:c", "deploy/sub/list.yaml:d",
+			},
+		},
+		{
+			name:     "non-recursive test",
+			patterns: []string{"deploy"},
+			options:  []func(*LoadOptions){WithRecursive(false)},
+			wanted:   []string{"deploy/a.yml:a1", "deploy/a.yml:a2", "deploy/b.yaml:b"},
+		},
+		{
+			name:     "extensions test",
+			patterns: []string{"deploy"},
+			options:  []func(*LoadOptions){WithExtensions(".json")},
+			wanted:   []string{"deploy/sub/c.json:c"},
+		},
+		{
+			name:     "uppercase extensions test",
+			patterns: []string{"deploy"},
+			options:  []func(*LoadOptions){WithExtensions(".JSON")},
+			wanted:   []string{"deploy/sub/c.json:c"},
+		},
+		{
+			name:     "glob test",
+			patterns: []string{"deploy/sub/*", "deploy/b.yaml"},
+			wanted:   []string{"deploy/b.yaml:b", "deploy/sub/c.json:c", "deploy/sub/list.yaml:d"},
+		},
+		{
+			name:     "no match test",
+			patterns: []string{"missing/*.yaml"},
+			isErr:    true,
+		},
+		{
+			name:     "invalid file test",
+			patterns: []string{"broken"},
+			isErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifests, err := LoadManifestsFromFS(newManifestFS(), tt.patterns, tt.options...)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s LoadManifestsFromFS() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			if got := manifestSummary(manifests); !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("LoadManifestsFromFS() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestLoadManifestsFromPaths(t *testing.T) {
+	dir := t.TempDir()
+	for name, file := range newManifestFS() {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, file.Data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifests, err := LoadManifestsFromPaths(
+		[]string{filepath.Join(dir, "deploy", "*.yaml")}, WithSourceAnnotation("example.com/source"),
+	)
+	if err != nil {
+		t.Fatalf("LoadManifestsFromPaths() unexpected error: %v", err)
+	}
+	source := filepath.Join(dir, "deploy", "b.yaml")
+	wanted := []string{source + ":b"}
+	if got := manifestSummary(manifests); !reflect.DeepEqual(got, wanted) {
+		t.Errorf("LoadManifestsFromPaths() = %v, want %v", got, wanted)
+	}
+	if got := manifests[0].Object.GetAnnotations()["example.com/source"]; got != source {
+		t.Errorf("LoadManifestsFromPaths() source annotation = %s, want %s", got, source)
+	}
+}
@@ -1,10 +1,23 @@
 package object
 
 import (
+	"maps"
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// SetMode defines how SetAnnotations and SetLabels apply the given entries to the object.
+type SetMode int
+
+const (
+	// MergeMode adds or updates the given entries and keeps the other existing ones.
+	MergeMode SetMode = iota
+	// ReplaceMode replaces all the existing entries with the given ones.
+	ReplaceMode
+)
+
 // ContainsAnnotation determines whether the object contains an annotation.
 func ContainsAnnotation(obj metav1.Object, key string) bool {
 	if _, ok := obj.GetAnnotations()[key]; ok {
@@ -35,6 +48,28 @@ func AddAnnotation(obj metav1.Object, k, v string) bool {
 	return true
 }
 
+// RemoveAnnotation removes the annotation from the object, returns true if the object's annotations are updated.
+func RemoveAnnotation(obj metav1.Object, key string) bool {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[key]; !ok {
+		return false
+	}
+
+	delete(annotations, key)
+	obj.SetAnnotations(annotations)
+	return true
+}
+
+// SetAnnotations applies the annotations to the object according to the mode, returns true if the object's
+// annotations are updated.
+func SetAnnotations(obj metav1.Object, annotations map[string]string, mode SetMode) bool {
+	result, updated := setStringMap(obj.GetAnnotations(), annotations, mode)
+	if updated {
+		obj.SetAnnotations(result)
+	}
+	return updated
+}
+
 // ContainsLabel determines whether the object contains a label.
 func ContainsLabel(obj metav1.Object, key string) bool {
 	if _, ok := obj.GetLabels()[key]; ok {
@@ -65,6 +100,27 @@ func AddLabel(obj metav1.Object, k, v string) bool {
 	return true
 }
 
+// RemoveLabel removes the label from the object, returns true if the object's labels are updated.
+func RemoveLabel(obj metav1.Object, key string) bool {
+	labels := obj.GetLabels()
+	if _, ok := labels[key]; !ok {
+		return false
+	}
+
+	delete(labels, key)
+	obj.SetLabels(labels)
+	return true
+}
+
+// SetLabels applies the labels to the object according to the mode, returns true if the object's labels are updated.
+func SetLabels(obj metav1.Object, labels map[string]string, mode SetMode) bool {
+	result, updated := setStringMap(obj.GetLabels(), labels, mode)
+	if updated {
+		obj.SetLabels(result)
+	}
+	return updated
+}
+
 // ContainsFinalizer determines whether the object contains a finalizer.
 func ContainsFinalizer(obj metav1.Object, finalizer string) bool {
 	finalizers := obj.GetFinalizers()
@@ -78,20 +134,76 @@ func ContainsFinalizer(obj metav1.Object, finalizer string) bool {
 
 // AddFinalizer adds a finalizer to the object, returns true if the object's finalizers are updated.
 func AddFinalizer(obj metav1.Object, finalizer string) bool {
-	finalizers := sets.New(obj.GetFinalizers()...)
-	if finalizers.Has(finalizer) {
+	return AddFinalizers(obj, finalizer)
+}
+
+// AddFinalizers adds the finalizers to the object, returns true if the object's finalizers are updated.
+// The order of the existing finalizers is preserved and the new ones are appended, duplicates are removed.
+func AddFinalizers(obj metav1.Object, finalizers ...string) bool {
+	current := obj.GetFinalizers()
+	result := make([]string, 0, len(current)+len(finalizers))
+	seen := sets.New[string]()
+	for _, item := range append(append([]string{}, current...), finalizers...) {
+		if seen.Has(item) {
+			continue
+		}
+		seen.Insert(item)
+		result = append(result, item)
+	}
+	if slices.Equal(current, result) {
 		return false
 	}
-	obj.SetFinalizers(finalizers.Insert(finalizer).UnsortedList())
+	obj.SetFinalizers(result)
 	return true
 }
 
 // RemoveFinalizer removes the finalizer from the object, returns true if the object's finalizers are updated.
 func RemoveFinalizer(obj metav1.Object, finalizer string) bool {
-	finalizers := sets.New(obj.GetFinalizers()...)
-	if !finalizers.Has(finalizer) {
+	return RemoveFinalizers(obj, finalizer)
+}
+
+// RemoveFinalizers removes the finalizers from the object, returns true if the object's finalizers are updated.
+// The order of the remaining finalizers is preserved.
+func RemoveFinalizers(obj metav1.Object, finalizers ...string) bool {
+	current := obj.GetFinalizers()
+	removed := sets.New(finalizers...)
+	result := make([]string, 0, len(current))
+	for _, item := range current {
+		if !removed.Has(item) {
+			result = append(result, item)
+		}
+	}
+	if len(result) == len(current) {
 		return false
 	}
-	obj.SetFinalizers(finalizers.Delete(finalizer).UnsortedList())
+	obj.SetFinalizers(result)
 	return true
 }
+
+// setStringMap applies the entries to the current map according to the mode, returns the result and whether it
+// differs from the current map.
+func setStringMap(current, entries map[string]string, mode SetMode) (map[string]string, bool) {
+	if mode == ReplaceMode {
+		if maps.Equal(current, entries) {
+			return current, false
+		}
+		result := make(map[string]string, len(entries))
+		for k, v := range entries {
+			result[k] = v
+		}
+		return result, true
+	}
+
+	updated := false
+	for k, v := range entries {
+		if old, ok := current[k]; ok && old == v {
+			continue
+		}
+		if current == nil {
+			current = make(map[string]string, len(entries))
+		}
+		current[k] = v
+		updated = true
+	}
+	return current, updated
+}
@@ -2,7 +2,6 @@ package object
 
 import (
 	"reflect"
-	"sort"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -390,6 +389,28 @@ func TestAddFinalizer(t *testing.T) {
 			updated:          false,
 			wantedFinalizers: []string{"aa", "bb"},
 		},
+		{
+			name: "order test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers: []string{"cc", "aa", "bb"},
+				},
+			},
+			finalizer:        "dd",
+			updated:          true,
+			wantedFinalizers: []string{"cc", "aa", "bb", "dd"},
+		},
+		{
+			name: "deduplicate test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers: []string{"bb", "aa", "bb"},
+				},
+			},
+			finalizer:        "aa",
+			updated:          true,
+			wantedFinalizers: []string{"bb", "aa"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -397,7 +418,6 @@ func TestAddFinalizer(t *testing.T) {
 				t.Errorf("AddFinalizer() = %v, want %v", got, tt.updated)
 			}
 			finalizers := tt.obj.GetFinalizers()
-			sort.Strings(finalizers)
 			if !reflect.DeepEqual(finalizers, tt.wantedFinalizers) {
 				t.Errorf("AddFinalizer() = %v, want %v", finalizers, tt.wantedFinalizers)
 			}
@@ -435,6 +455,17 @@ func TestRemoveFinalizer(t *testing.T) {
 			updated:          false,
 			wantedFinalizers: []string{"aa", "bb"},
 		},
+		{
+			name: "order test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers: []string{"cc", "aa", "bb", "aa"},
+				},
+			},
+			finalizer:        "aa",
+			updated:          true,
+			wantedFinalizers: []string{"cc", "bb"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -442,10 +473,393 @@ func TestRemoveFinalizer(t *testing.T) {
 				t.Errorf("RemoveFinalizer() = %v, want %v", got, tt.updated)
 			}
 			finalizers := tt.obj.GetFinalizers()
-			sort.Strings(finalizers)
 			if !reflect.DeepEqual(finalizers, tt.wantedFinalizers) {
 				t.Errorf("RemoveFinalizer() = %v, want %v", finalizers, tt.wantedFinalizers)
 			}
 		})
 	}
 }
+
+func TestRemoveAnnotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     metav1.Object
+		key     string
+		updated bool
+		wanted  map[string]string
+	}{
+		{
+			name: "normal test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"aa": "bb",
+						"cc": "dd",
+					},
+				},
+			},
+			key:     "aa",
+			updated: true,
+			wanted: map[string]string{
+				"cc": "dd",
+			},
+		},
+		{
+			name: "not updated test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"aa": "bb",
+					},
+				},
+			},
+			key:     "cc",
+			updated: false,
+			wanted: map[string]string{
+				"aa": "bb",
+			},
+		},
+		{
+			name:    "nil test",
+			obj:     &corev1.Node{},
+			key:     "aa",
+			updated: false,
+			wanted:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemoveAnnotation(tt.obj, tt.key); got != tt.updated {
+				t.Errorf("RemoveAnnotation() = %v, want %v", got, tt.updated)
+			}
+			if !reflect.DeepEqual(tt.obj.GetAnnotations(), tt.wanted) {
+				t.Errorf("RemoveAnnotation() = %v, want %v", tt.obj.GetAnnotations(), tt.wanted)
+			}
+		})
+	}
+}
+
+func TestSetAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		obj         metav1.Object
+		annotations map[string]string
+		mode        SetMode
+		updated     bool
+		wanted      map[string]string
+	}{
+		{
+			name: "merge test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"aa": "bb",
+						"cc": "dd",
+					},
+				},
+			},
+			annotations: map[string]string{
+				"aa": "xx",
+				"ee": "ff",
+			},
+			mode:    MergeMode,
+			updated: true,
+			wanted: map[string]string{
+				"aa": "xx",
+				"cc": "dd",
+				"ee": "ff",
+			},
+		},
+		{
+			name: "merge not updated test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"aa": "bb",
+						"cc": "dd",
+					},
+				},
+			},
+			annotations: map[string]string{
+				"aa": "bb",
+			},
+			mode:    MergeMode,
+			updated: false,
+			wanted: map[string]string{
+				"aa": "bb",
+				"cc": "dd",
+			},
+		},
+		{
+			name: "merge nil test",
+			obj:  &corev1.Node{},
+			annotations: map[string]string{
+				"aa": "bb",
+			},
+			mode:    MergeMode,
+			updated: true,
+			wanted: map[string]string{
+				"aa": "bb",
+			},
+		},
+		{
+			name: "replace test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"aa": "bb",
+						"cc": "dd",
+					},
+				},
+			},
+			annotations: map[string]string{
+				"aa": "bb",
+			},
+			mode:    ReplaceMode,
+			updated: true,
+			wanted: map[string]string{
+				"aa": "bb",
+			},
+		},
+		{
+			name: "replace not updated test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"aa": "bb",
+					},
+				},
+			},
+			annotations: map[string]string{
+				"aa": "bb",
+			},
+			mode:    ReplaceMode,
+			updated: false,
+			wanted: map[string]string{
+				"aa": "bb",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SetAnnotations(tt.obj, tt.annotations, tt.mode); got != tt.updated {
+				t.Errorf("SetAnnotations() = %v, want %v", got, tt.updated)
+			}
+			if !reflect.DeepEqual(tt.obj.GetAnnotations(), tt.wanted) {
+				t.Errorf("SetAnnotations() = %v, want %v", tt.obj.GetAnnotations(), tt.wanted)
+			}
+		})
+	}
+}
+
+func TestRemoveLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     metav1.Object
+		key     string
+		updated bool
+		wanted  map[string]string
+	}{
+		{
+			name: "normal test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"aa": "bb",
+						"cc": "dd",
+					},
+				},
+			},
+			key:     "aa",
+			updated: true,
+			wanted: map[string]string{
+				"cc": "dd",
+			},
+		},
+		{
+			name: "not updated test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"aa": "bb",
+					},
+				},
+			},
+			key:     "cc",
+			updated: false,
+			wanted: map[string]string{
+				"aa": "bb",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemoveLabel(tt.obj, tt.key); got != tt.updated {
+				t.Errorf("RemoveLabel() = %v, want %v", got, tt.updated)
+			}
+			if !reflect.DeepEqual(tt.obj.GetLabels(), tt.wanted) {
+				t.Errorf("RemoveLabel() = %v, want %v", tt.obj.GetLabels(), tt.wanted)
+			}
+		})
+	}
+}
+
+func TestSetLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     metav1.Object
+		labels  map[string]string
+		mode    SetMode
+		updated bool
+		wanted  map[string]string
+	}{
+		{
+			name: "merge test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"aa": "bb",
+					},
+				},
+			},
+			labels: map[string]string{
+				"cc": "dd",
+			},
+			mode:    MergeMode,
+			updated: true,
+			wanted: map[string]string{
+				"aa": "bb",
+				"cc": "dd",
+			},
+		},
+		{
+			name: "replace test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"aa": "bb",
+					},
+				},
+			},
+			labels: map[string]string{
+				"cc": "dd",
+			},
+			mode:    ReplaceMode,
+			updated: true,
+			wanted: map[string]string{
+				"cc": "dd",
+			},
+		},
+		{
+			name:    "replace nil test",
+			obj:     &corev1.Node{},
+			labels:  map[string]string{},
+			mode:    ReplaceMode,
+			updated: false,
+			wanted:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SetLabels(tt.obj, tt.labels, tt.mode); got != tt.updated {
+				t.Errorf("SetLabels() = %v, want %v", got, tt.updated)
+			}
+			if !reflect.DeepEqual(tt.obj.GetLabels(), tt.wanted) {
+				t.Errorf("SetLabels() = %v, want %v", tt.obj.GetLabels(), tt.wanted)
+			}
+		})
+	}
+}
+
+func TestAddFinalizers(t *testing.T) {
+	tests := []struct {
+		name             string
+		obj              metav1.Object
+		finalizers       []string
+		updated          bool
+		wantedFinalizers []string
+	}{
+		{
+			name: "updated test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers: []string{"bb", "aa"},
+				},
+			},
+			finalizers:       []string{"dd", "aa", "cc", "dd"},
+			updated:          true,
+			wantedFinalizers: []string{"bb", "aa", "dd", "cc"},
+		},
+		{
+			name: "not updated test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers: []string{"bb", "aa"},
+				},
+			},
+			finalizers:       []string{"aa", "bb"},
+			updated:          false,
+			wantedFinalizers: []string{"bb", "aa"},
+		},
+		{
+			name:             "nil test",
+			obj:              &corev1.Node{},
+			finalizers:       []string{"aa"},
+			updated:          true,
+			wantedFinalizers: []string{"aa"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddFinalizers(tt.obj, tt.finalizers...); got != tt.updated {
+				t.Errorf("AddFinalizers() = %v, want %v", got, tt.updated)
+			}
+			if !reflect.DeepEqual(tt.obj.GetFinalizers(), tt.wantedFinalizers) {
+				t.Errorf("AddFinalizers() = %v, want %v", tt.obj.GetFinalizers(), tt.wantedFinalizers)
+			}
+		})
+	}
+}
+
+func TestRemoveFinalizers(t *testing.T) {
+	tests := []struct {
+		name             string
+		obj              metav1.Object
+		finalizers       []string
+		updated          bool
+		wantedFinalizers []string
+	}{
+		{
+			name: "updated test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers: []string{"cc", "aa", "bb", "dd"},
+				},
+			},
+			finalizers:       []string{"aa", "dd", "ee"},
+			updated:          true,
+			wantedFinalizers: []string{"cc", "bb"},
+		},
+		{
+			name: "not updated test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers: []string{"aa", "bb"},
+				},
+			},
+			finalizers:       []string{"cc"},
+			updated:          false,
+			wantedFinalizers: []string{"aa", "bb"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemoveFinalizers(tt.obj, tt.finalizers...); got != tt.updated {
+				t.Errorf("RemoveFinalizers() = %v, want %v", got, tt.updated)
+			}
+			if !reflect.DeepEqual(tt.obj.GetFinalizers(), tt.wantedFinalizers) {
+				t.Errorf("RemoveFinalizers() = %v, want %v", tt.obj.GetFinalizers(), tt.wantedFinalizers)
+			}
+		})
+	}
+}
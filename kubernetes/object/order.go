@@ -0,0 +1,132 @@
+package object
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CustomResourceDefinitionKind is the kind of the CustomResourceDefinition objects.
+const CustomResourceDefinitionKind = "CustomResourceDefinition"
+
+// KindSortOrder is the order in which the objects of the kinds are installed, the kinds not in the list are installed
+// after all the listed ones.
+type KindSortOrder []string
+
+// InstallOrder is the default install order, it is the same as the one of Helm.
+var InstallOrder = KindSortOrder{
+	"PriorityClass",
+	"Namespace",
+	"NetworkPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"PodSecurityPolicy",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"SecretList",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	CustomResourceDefinitionKind,
+	"ClusterRole",
+	"ClusterRoleList",
+	"ClusterRoleBinding",
+	"ClusterRoleBindingList",
+	"Role",
+	"RoleList",
+	"RoleBinding",
+	"RoleBindingList",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"IngressClass",
+	"Ingress",
+	"APIService",
+}
+
+// SortOptions defines options needed to sort objects.
+type SortOptions struct {
+	order KindSortOrder
+}
+
+// WithKindSortOrder sets the install order of the kinds.
+// The default value is InstallOrder.
+func WithKindSortOrder(order KindSortOrder) func(*SortOptions) {
+	return func(o *SortOptions) {
+		o.order = order
+	}
+}
+
+// SortByInstallOrder sorts the objects in place by their kinds in the order they should be installed, so that the
+// objects are created after the ones they depend on (eg: Namespaces and ServiceAccounts before Deployments). The
+// custom resources whose CRDs are in the list are placed after the CRDs, and the objects of the same kind keep their
+// relative order.
+func SortByInstallOrder(objs []*unstructured.Unstructured, options ...func(*SortOptions)) {
+	ranks := kindRanks(objs, options...)
+	sort.SliceStable(objs, func(i, j int) bool {
+		return ranks[objs[i]] < ranks[objs[j]]
+	})
+}
+
+// SortByUninstallOrder sorts the objects in place by their kinds in the reverse install order, so that the objects
+// are deleted before the ones they depend on.
+func SortByUninstallOrder(objs []*unstructured.Unstructured, options ...func(*SortOptions)) {
+	ranks := kindRanks(objs, options...)
+	sort.SliceStable(objs, func(i, j int) bool {
+		return ranks[objs[i]] > ranks[objs[j]]
+	})
+}
+
+// kindRanks returns the install rank of each object.
+func kindRanks(objs []*unstructured.Unstructured, options ...func(*SortOptions)) map[*unstructured.Unstructured]int {
+	opts := &SortOptions{
+		order: InstallOrder,
+	}
+	for _, f := range options {
+		f(opts)
+	}
+
+	tableRanks := make(map[string]int, len(opts.order))
+	for i, kind := range opts.order {
+		if _, ok := tableRanks[kind]; !ok {
+			tableRanks[kind] = i
+		}
+	}
+	rankOf := func(kind string) int {
+		if rank, ok := tableRanks[kind]; ok {
+			return rank
+		}
+		return len(opts.order)
+	}
+
+	customKinds := make(map[schema.GroupKind]bool)
+	for _, obj := range objs {
+		if obj.GetKind() != CustomResourceDefinitionKind {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+		customKinds[schema.GroupKind{Group: group, Kind: kind}] = true
+	}
+
+	crdRank := rankOf(CustomResourceDefinitionKind)
+	ranks := make(map[*unstructured.Unstructured]int, len(objs))
+	for _, obj := range objs {
+		rank := rankOf(obj.GetKind())
+		if customKinds[obj.GroupVersionKind().GroupKind()] && rank <= crdRank {
+			rank = crdRank + 1
+		}
+		ranks[obj] = rank
+	}
+	return ranks
+}
@@ -0,0 +1,85 @@
+package object
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newOrderObject(apiVersion, kind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	return obj
+}
+
+func newOrderObjects() []*unstructured.Unstructured {
+	crd := newOrderObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "foos.example.com")
+	_ = unstructured.SetNestedField(crd.Object, "example.com", "spec", "group")
+	_ = unstructured.SetNestedField(crd.Object, "Foo", "spec", "names", "kind")
+	return []*unstructured.Unstructured{
+		newOrderObject("apps/v1", "Deployment", "web"),
+		newOrderObject("example.com/v1", "Foo", "foo"),
+		newOrderObject("v1", "Service", "web"),
+		newOrderObject("example.com/v1", "Bar", "bar"),
+		crd,
+		newOrderObject("v1", "ConfigMap", "config"),
+		newOrderObject("v1", "Namespace", "ns"),
+		newOrderObject("v1", "ConfigMap", "config2"),
+	}
+}
+
+func orderSummary(objs []*unstructured.Unstructured) []string {
+	result := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		result = append(result, obj.GetKind()+"/"+obj.GetName())
+	}
+	return result
+}
+
+func TestSortByInstallOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []func(*SortOptions)
+		wanted  []string
+	}{
+		{
+			name: "default order test",
+			wanted: []string{
+				"Namespace/ns", "ConfigMap/config", "ConfigMap/config2", "CustomResourceDefinition/foos.example.com",
+				"Service/web", "Deployment/web", "Foo/foo", "Bar/bar",
+			},
+		},
+		{
+			name:    "custom order test",
+			options: []func(*SortOptions){WithKindSortOrder(KindSortOrder{"Foo", "CustomResourceDefinition", "Service"})},
+			wanted: []string{
+				"CustomResourceDefinition/foos.example.com", "Foo/foo", "Service/web",
+				"Deployment/web", "Bar/bar", "ConfigMap/config", "Namespace/ns", "ConfigMap/config2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := newOrderObjects()
+			SortByInstallOrder(objs, tt.options...)
+			if got := orderSummary(objs); !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("SortByInstallOrder() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestSortByUninstallOrder(t *testing.T) {
+	objs := newOrderObjects()
+	SortByUninstallOrder(objs)
+	wanted := []string{
+		"Foo/foo", "Bar/bar", "Deployment/web", "Service/web", "CustomResourceDefinition/foos.example.com",
+		"ConfigMap/config", "ConfigMap/config2", "Namespace/ns",
+	}
+	if got := orderSummary(objs); !reflect.DeepEqual(got, wanted) {
+		t.Errorf("SortByUninstallOrder() = %v, want %v", got, wanted)
+	}
+}
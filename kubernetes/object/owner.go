@@ -0,0 +1,197 @@
+package object
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// SetControllerReference sets the owner as the controller owner of the object, returns true if the object's owner
+// references are updated. An error is returned if the object already has a different controller owner or the
+// ownership is invalid (cross-namespace or cluster-scoped object owned by a namespaced one).
+func SetControllerReference(owner, obj client.Object, scheme *runtime.Scheme) (bool, error) {
+	ref, err := newOwnerReference(owner, obj, scheme)
+	if err != nil {
+		return false, err
+	}
+	ref.Controller = ptr.To(true)
+	ref.BlockOwnerDeletion = ptr.To(true)
+
+	if existing := metav1.GetControllerOf(obj); existing != nil && !referSameObject(*existing, ref) {
+		return false, fmt.Errorf("object %s/%s is already controlled by %s %s",
+			obj.GetNamespace(), obj.GetName(), existing.Kind, existing.Name)
+	}
+	return upsertOwnerReference(obj, ref), nil
+}
+
+// AddOwnerReference adds the owner as a non-controller owner of the object, returns true if the object's owner
+// references are updated. An error is returned if the ownership is invalid.
+func AddOwnerReference(owner, obj client.Object, scheme *runtime.Scheme) (bool, error) {
+	ref, err := newOwnerReference(owner, obj, scheme)
+	if err != nil {
+		return false, err
+	}
+	for _, existing := range obj.GetOwnerReferences() {
+		if referSameObject(existing, ref) && existing.Controller != nil && *existing.Controller {
+			// keep the controller reference as it is
+			return false, nil
+		}
+	}
+	return upsertOwnerReference(obj, ref), nil
+}
+
+// RemoveOwnerReference removes the owner references pointing to the owner from the object, returns true if the
+// object's owner references are updated.
+func RemoveOwnerReference(owner, obj metav1.Object) bool {
+	refs := obj.GetOwnerReferences()
+	result := make([]metav1.OwnerReference, 0, len(refs))
+	for _, ref := range refs {
+		if ref.UID != owner.GetUID() {
+			result = append(result, ref)
+		}
+	}
+	if len(result) == len(refs) {
+		return false
+	}
+	obj.SetOwnerReferences(result)
+	return true
+}
+
+// IsOwnedBy determines whether the object has an owner reference pointing to the owner.
+func IsOwnedBy(obj, owner metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsControlledBy determines whether the owner is the controller owner of the object.
+func IsControlledBy(obj, owner metav1.Object) bool {
+	ref := metav1.GetControllerOf(obj)
+	return ref != nil && ref.UID == owner.GetUID()
+}
+
+// GetControllerOwner returns the controller owner reference of the object, nil is returned if it has none.
+func GetControllerOwner(obj metav1.Object) *metav1.OwnerReference {
+	return metav1.GetControllerOfNoCopy(obj)
+}
+
+// ResolveOwnerChain follows the controller owner references of the object and returns its owners from the closest
+// to the furthest one (eg: ReplicaSet, Deployment for a Pod). The owners are returned as unstructured objects and
+// the chain stops at the first owner that has no controller or cannot be found, in which case the owners resolved
+// so far are returned without an error.
+func ResolveOwnerChain(ctx context.Context, reader client.Reader, obj client.Object) ([]client.Object, error) {
+	owners := make([]client.Object, 0)
+	visited := map[types.UID]bool{obj.GetUID(): true}
+	current := obj
+	for {
+		ref := metav1.GetControllerOf(current)
+		if ref == nil {
+			return owners, nil
+		}
+		if visited[ref.UID] {
+			return nil, fmt.Errorf("owner reference cycle detected at %s %s", ref.Kind, ref.Name)
+		}
+		visited[ref.UID] = true
+
+		owner := &unstructured.Unstructured{}
+		owner.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+		// the namespace is ignored by the client for cluster-scoped owners
+		key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: ref.Name}
+		if err := reader.Get(ctx, key, owner); err != nil {
+			if apierrors.IsNotFound(err) {
+				return owners, nil
+			}
+			return nil, err
+		}
+		if owner.GetUID() != ref.UID {
+			// the owner has been deleted and recreated
+			return owners, nil
+		}
+		owners = append(owners, owner)
+		current = owner
+	}
+}
+
+// ValidateOwner checks whether the owner can own the object: a namespaced owner must be in the same namespace as
+// the object and a cluster-scoped object cannot be owned by a namespaced one.
+func ValidateOwner(owner, obj metav1.Object) error {
+	ownerNamespace := owner.GetNamespace()
+	if ownerNamespace == "" {
+		return nil
+	}
+	objNamespace := obj.GetNamespace()
+	if objNamespace == "" {
+		return fmt.Errorf("cluster-scoped object %s cannot be owned by namespaced object %s/%s",
+			obj.GetName(), ownerNamespace, owner.GetName())
+	}
+	if objNamespace != ownerNamespace {
+		return fmt.Errorf("object %s/%s cannot be owned by object %s/%s in a different namespace",
+			objNamespace, obj.GetName(), ownerNamespace, owner.GetName())
+	}
+	return nil
+}
+
+func newOwnerReference(owner, obj client.Object, scheme *runtime.Scheme) (metav1.OwnerReference, error) {
+	if err := ValidateOwner(owner, obj); err != nil {
+		return metav1.OwnerReference{}, err
+	}
+	gvk, err := apiutil.GVKForObject(owner, scheme)
+	if err != nil {
+		return metav1.OwnerReference{}, err
+	}
+	return metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       owner.GetName(),
+		UID:        owner.GetUID(),
+	}, nil
+}
+
+// upsertOwnerReference adds the reference to the object or updates the existing one pointing to the same owner,
+// returns true if the object's owner references are updated.
+func upsertOwnerReference(obj metav1.Object, ref metav1.OwnerReference) bool {
+	refs := obj.GetOwnerReferences()
+	for i, existing := range refs {
+		if !referSameObject(existing, ref) {
+			continue
+		}
+		if equalOwnerReference(existing, ref) {
+			return false
+		}
+		refs[i] = ref
+		obj.SetOwnerReferences(refs)
+		return true
+	}
+	obj.SetOwnerReferences(append(refs, ref))
+	return true
+}
+
+// referSameObject determines whether the references point to the same object, the versions are not compared.
+func referSameObject(a, b metav1.OwnerReference) bool {
+	aGV, err := schema.ParseGroupVersion(a.APIVersion)
+	if err != nil {
+		return false
+	}
+	bGV, err := schema.ParseGroupVersion(b.APIVersion)
+	if err != nil {
+		return false
+	}
+	return aGV.Group == bGV.Group && a.Kind == b.Kind && a.Name == b.Name
+}
+
+func equalOwnerReference(a, b metav1.OwnerReference) bool {
+	return a.APIVersion == b.APIVersion && a.Kind == b.Kind && a.Name == b.Name && a.UID == b.UID &&
+		ptr.Equal(a.Controller, b.Controller) && ptr.Equal(a.BlockOwnerDeletion, b.BlockOwnerDeletion)
+}
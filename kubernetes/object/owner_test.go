@@ -0,0 +1,177 @@
+package object
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSetControllerReference(t *testing.T) {
+	owner := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "default", UID: "1"},
+	}
+
+	tests := []struct {
+		name    string
+		owner   client.Object
+		obj     client.Object
+		isErr   bool
+		updated bool
+	}{
+		{
+			name:    "normal test",
+			owner:   owner,
+			obj:     &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "default"}},
+			updated: true,
+		},
+		{
+			name:  "not updated test",
+			owner: owner,
+			obj: &appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "rs", Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "apps/v1", Kind: "Deployment", Name: "deploy", UID: "1",
+						Controller: ptr.To(true), BlockOwnerDeletion: ptr.To(true),
+					}},
+				},
+			},
+			updated: false,
+		},
+		{
+			name:  "already owned test",
+			owner: owner,
+			obj: &appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "rs", Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "apps/v1", Kind: "Deployment", Name: "other", UID: "2", Controller: ptr.To(true),
+					}},
+				},
+			},
+			isErr: true,
+		},
+		{
+			name:  "cross namespace test",
+			owner: owner,
+			obj:   &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "other"}},
+			isErr: true,
+		},
+		{
+			name:  "cluster-scoped object test",
+			owner: owner,
+			obj:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := SetControllerReference(tt.owner, tt.obj, clientgoscheme.Scheme)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s SetControllerReference() unexpected error: %v", tt.name, err)
+			}
+			if updated != tt.updated {
+				t.Errorf("SetControllerReference() = %v, want %v", updated, tt.updated)
+			}
+			if tt.isErr {
+				return
+			}
+			if !IsControlledBy(tt.obj, tt.owner) || !IsOwnedBy(tt.obj, tt.owner) {
+				t.Errorf("SetControllerReference() = %v, want controlled by %s", tt.obj.GetOwnerReferences(), tt.owner.GetName())
+			}
+			if ref := GetControllerOwner(tt.obj); ref == nil || ref.Kind != "Deployment" || ref.APIVersion != "apps/v1" {
+				t.Errorf("GetControllerOwner() = %v", ref)
+			}
+		})
+	}
+}
+
+func TestAddAndRemoveOwnerReference(t *testing.T) {
+	owner1 := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "default", UID: "1"}}
+	owner2 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node", UID: "2"}}
+	obj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "default"}}
+
+	for _, owner := range []client.Object{owner1, owner2} {
+		if updated, err := AddOwnerReference(owner, obj, clientgoscheme.Scheme); err != nil || !updated {
+			t.Fatalf("AddOwnerReference() = %v, %v", updated, err)
+		}
+	}
+	if updated, err := AddOwnerReference(owner1, obj, clientgoscheme.Scheme); err != nil || updated {
+		t.Errorf("AddOwnerReference() = %v, %v, want not updated", updated, err)
+	}
+	if len(obj.OwnerReferences) != 2 || GetControllerOwner(obj) != nil {
+		t.Errorf("AddOwnerReference() = %v", obj.OwnerReferences)
+	}
+
+	if !RemoveOwnerReference(owner1, obj) || RemoveOwnerReference(owner1, obj) {
+		t.Errorf("RemoveOwnerReference() returned wrong results")
+	}
+	if IsOwnedBy(obj, owner1) || !IsOwnedBy(obj, owner2) {
+		t.Errorf("RemoveOwnerReference() = %v", obj.OwnerReferences)
+	}
+}
+
+func TestResolveOwnerChain(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "default", UID: "1"},
+	}
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "default", UID: "2"},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default", UID: "3"},
+	}
+	if _, err := SetControllerReference(deploy, rs, clientgoscheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SetControllerReference(rs, pod, clientgoscheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		objs   []runtime.Object
+		isErr  bool
+		wanted []string
+	}{
+		{
+			name:   "normal test",
+			objs:   []runtime.Object{deploy, rs},
+			wanted: []string{"ReplicaSet/rs", "Deployment/deploy"},
+		},
+		{
+			name:   "not found test",
+			objs:   []runtime.Object{rs},
+			wanted: []string{"ReplicaSet/rs"},
+		},
+		{
+			name:   "no owner found test",
+			wanted: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClient(tt.objs...)
+			owners, err := ResolveOwnerChain(context.TODO(), c, pod)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s ResolveOwnerChain() unexpected error: %v", tt.name, err)
+			}
+			got := make([]string, 0, len(owners))
+			for _, owner := range owners {
+				got = append(got, owner.GetObjectKind().GroupVersionKind().Kind+"/"+owner.GetName())
+			}
+			if !tt.isErr && !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("ResolveOwnerChain() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package object
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	liamaps "github.com/iawia002/lia/maps"
+)
+
+// PatchTarget selects the objects a patch is applied to, the empty fields match all the objects.
+type PatchTarget struct {
+	Group     string
+	Version   string
+	Kind      string
+	Namespace string
+	Name      string
+	// LabelSelector is a label selector string, eg: app=web,tier!=db
+	LabelSelector string
+}
+
+// Matches determines whether the object is selected by the target.
+func (t *PatchTarget) Matches(obj *unstructured.Unstructured) (bool, error) {
+	gvk := obj.GroupVersionKind()
+	if t.Group != "" && t.Group != gvk.Group ||
+		t.Version != "" && t.Version != gvk.Version ||
+		t.Kind != "" && t.Kind != gvk.Kind ||
+		t.Namespace != "" && t.Namespace != obj.GetNamespace() ||
+		t.Name != "" && t.Name != obj.GetName() {
+		return false, nil
+	}
+	if t.LabelSelector == "" {
+		return true, nil
+	}
+	selector, err := labels.Parse(t.LabelSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(obj.GetLabels())), nil
+}
+
+// PatchOptions defines options needed to apply patches.
+type PatchOptions struct {
+	scheme *runtime.Scheme
+}
+
+// WithPatchScheme sets the scheme used to look up the typed objects whose patch strategies are used by strategic
+// merge patches, the objects not in the scheme (eg: custom resources) are patched as JSON merge patches.
+// The default value is Kubernetes scheme.
+func WithPatchScheme(scheme *runtime.Scheme) func(*PatchOptions) {
+	return func(o *PatchOptions) {
+		o.scheme = scheme
+	}
+}
+
+// ApplyStrategicMergePatch applies the strategic merge patch (YAML or JSON) to the objects selected by the target in
+// place and returns the number of patched objects. If the target is nil, the objects are selected by the apiVersion,
+// kind, name and namespace of the patch itself, like the patchesStrategicMerge of kustomize.
+//
+// eg:
+//
+//	n, err := ApplyStrategicMergePatch(objs, []byte(`
+//	apiVersion: apps/v1
+//	kind: Deployment
+//	metadata:
+//	  name: web
+//	spec:
+//	  replicas: 3
+//	`), nil)
+func ApplyStrategicMergePatch(
+	objs []*unstructured.Unstructured, patch []byte, target *PatchTarget, options ...func(*PatchOptions),
+) (int, error) {
+	opts := &PatchOptions{
+		scheme: clientgoscheme.Scheme,
+	}
+	for _, f := range options {
+		f(opts)
+	}
+
+	patchMap, err := unmarshalObject(patch)
+	if err != nil {
+		return 0, err
+	}
+	patchObj := &unstructured.Unstructured{Object: patchMap}
+	if target == nil {
+		gvk := patchObj.GroupVersionKind()
+		target = &PatchTarget{
+			Group:     gvk.Group,
+			Version:   gvk.Version,
+			Kind:      gvk.Kind,
+			Namespace: patchObj.GetNamespace(),
+			Name:      patchObj.GetName(),
+		}
+		if target.Kind == "" || target.Name == "" {
+			return 0, errors.New("the patch must have a kind and name if there is no target")
+		}
+	} else {
+		// the patch must not rename the selected objects
+		unstructured.RemoveNestedField(patchMap, "metadata", "name")
+		unstructured.RemoveNestedField(patchMap, "metadata", "namespace")
+	}
+
+	return patchObjects(objs, target, func(obj *unstructured.Unstructured) (map[string]interface{}, error) {
+		typed, err := opts.scheme.New(obj.GroupVersionKind())
+		if err != nil {
+			if !runtime.IsNotRegisteredError(err) {
+				return nil, err
+			}
+			return mergePatch(obj.Object, patchMap), nil
+		}
+		return strategicpatch.StrategicMergeMapPatch(obj.Object, patchMap, typed)
+	})
+}
+
+// ApplyJSON6902Patch applies the JSON patch (a YAML or JSON list of RFC 6902 operations) to the objects selected by
+// the target in place and returns the number of patched objects.
+//
+// eg:
+//
+//	n, err := ApplyJSON6902Patch(objs, []byte(`
+//	- op: replace
+//	  path: /spec/replicas
+//	  value: 3
+//	`), PatchTarget{Kind: "Deployment", Name: "web"})
+func ApplyJSON6902Patch(objs []*unstructured.Unstructured, patch []byte, target PatchTarget) (int, error) {
+	operations := liamaps.Patch{}
+	if err := yaml.Unmarshal(patch, &operations); err != nil {
+		return 0, err
+	}
+	for i := range operations {
+		// decode the integers as int64 like the other unstructured objects, instead of float64
+		data, err := json.Marshal(operations[i].Value)
+		if err != nil {
+			return 0, err
+		}
+		if err = utiljson.Unmarshal(data, &operations[i].Value); err != nil {
+			return 0, err
+		}
+	}
+	return patchObjects(objs, &target, func(obj *unstructured.Unstructured) (map[string]interface{}, error) {
+		return liamaps.Apply(obj.Object, operations)
+	})
+}
+
+// patchObjects replaces the content of the objects selected by the target with the result of the patch function.
+func patchObjects(
+	objs []*unstructured.Unstructured, target *PatchTarget,
+	patch func(obj *unstructured.Unstructured) (map[string]interface{}, error),
+) (int, error) {
+	count := 0
+	for _, obj := range objs {
+		matched, err := target.Matches(obj)
+		if err != nil {
+			return count, err
+		}
+		if !matched {
+			continue
+		}
+		result, err := patch(obj)
+		if err != nil {
+			return count, fmt.Errorf("patch %s %s: %v", obj.GetKind(), referenceName(obj), err)
+		}
+		obj.Object = result
+		count++
+	}
+	return count, nil
+}
+
+// mergePatch applies the JSON merge patch (RFC 7386) to a copy of the source map: maps are merged recursively, null
+// values remove the fields, and the other values replace the existing ones.
+func mergePatch(src, patch map[string]interface{}) map[string]interface{} {
+	result := runtime.DeepCopyJSON(src)
+	for k, v := range patch {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+		patchValue, isMap := v.(map[string]interface{})
+		srcValue, srcIsMap := result[k].(map[string]interface{})
+		switch {
+		case isMap && srcIsMap:
+			result[k] = mergePatch(srcValue, patchValue)
+		case isMap:
+			result[k] = mergePatch(map[string]interface{}{}, patchValue)
+		default:
+			result[k] = runtime.DeepCopyJSONValue(v)
+		}
+	}
+	return result
+}
+
+// referenceName returns the namespace/name of the object, or its name if it is cluster-scoped.
+func referenceName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
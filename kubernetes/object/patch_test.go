@@ -0,0 +1,177 @@
+package object
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const patchManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:v1
+      - name: sidecar
+        image: sidecar:v1
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  labels:
+    app: api
+spec:
+  replicas: 1
+---
+apiVersion: example.com/v1
+kind: Foo
+metadata:
+  name: foo
+spec:
+  a: 1
+  b:
+    c: 2
+    d: 3
+`
+
+func newPatchObjects(t *testing.T) []*unstructured.Unstructured {
+	objs, err := LoadManifests(strings.NewReader(patchManifests))
+	if err != nil {
+		t.Fatalf("LoadManifests() unexpected error: %v", err)
+	}
+	return objs
+}
+
+func TestApplyStrategicMergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		patch  string
+		target *PatchTarget
+		isErr  bool
+		count  int
+		// indices of the objects to check
+		indices []int
+		field   []string
+		wanted  interface{}
+	}{
+		{
+			name: "merge containers test",
+			patch: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:v2
+`,
+			count:   1,
+			indices: []int{0},
+			field:   []string{"spec", "template", "spec", "containers"},
+			wanted: []interface{}{
+				map[string]interface{}{"name": "web", "image": "web:v2"},
+				map[string]interface{}{"name": "sidecar", "image": "sidecar:v1"},
+			},
+		},
+		{
+			name: "label selector target test",
+			patch: `metadata:
+  name: ignored
+spec:
+  replicas: 3
+`,
+			target:  &PatchTarget{Kind: "Deployment", LabelSelector: "app in (web, api)"},
+			count:   2,
+			indices: []int{0, 1},
+			field:   []string{"spec", "replicas"},
+			wanted:  int64(3),
+		},
+		{
+			name: "custom resource test",
+			patch: `apiVersion: example.com/v1
+kind: Foo
+metadata:
+  name: foo
+spec:
+  a: null
+  b:
+    d: 4
+`,
+			count:   1,
+			indices: []int{2},
+			field:   []string{"spec"},
+			wanted:  map[string]interface{}{"b": map[string]interface{}{"c": int64(2), "d": int64(4)}},
+		},
+		{
+			name:  "no target test",
+			patch: "spec:\n  replicas: 3\n",
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := newPatchObjects(t)
+			count, err := ApplyStrategicMergePatch(objs, []byte(tt.patch), tt.target)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s ApplyStrategicMergePatch() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			if count != tt.count {
+				t.Errorf("ApplyStrategicMergePatch() = %d, want %d", count, tt.count)
+			}
+			for _, i := range tt.indices {
+				obj := objs[i]
+				got, _, _ := unstructured.NestedFieldNoCopy(obj.Object, tt.field...)
+				if !reflect.DeepEqual(got, tt.wanted) {
+					t.Errorf("ApplyStrategicMergePatch() %s = %v, want %v", strings.Join(tt.field, "."), got, tt.wanted)
+				}
+				if obj.GetName() == "ignored" {
+					t.Errorf("ApplyStrategicMergePatch() renamed the object")
+				}
+			}
+		})
+	}
+}
+
+func TestApplyJSON6902Patch(t *testing.T) {
+	objs := newPatchObjects(t)
+	count, err := ApplyJSON6902Patch(objs, []byte(`
+- op: replace
+  path: /spec/replicas
+  value: 5
+- op: add
+  path: /metadata/labels/tier
+  value: backend
+`), PatchTarget{Group: "apps", Kind: "Deployment", Name: "api"})
+	if err != nil {
+		t.Fatalf("ApplyJSON6902Patch() unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("ApplyJSON6902Patch() = %d, want 1", count)
+	}
+	if replicas, _, _ := unstructured.NestedInt64(objs[1].Object, "spec", "replicas"); replicas != 5 {
+		t.Errorf("ApplyJSON6902Patch() replicas = %d, want 5", replicas)
+	}
+	if tier := objs[1].GetLabels()["tier"]; tier != "backend" {
+		t.Errorf("ApplyJSON6902Patch() tier label = %s, want backend", tier)
+	}
+
+	_, err = ApplyJSON6902Patch(objs, []byte(`[{"op": "remove", "path": "/spec/missing"}]`), PatchTarget{Kind: "Foo"})
+	if err == nil {
+		t.Errorf("ApplyJSON6902Patch() expected an error for a missing path")
+	}
+}
@@ -0,0 +1,149 @@
+package object
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Reference is the canonical identity of an object, formatted as [group/]version/Kind/[namespace/]name[@uid]
+// (eg: apps/v1/Deployment/default/web, v1/Node/node1) for logs, queue keys and events.
+type Reference struct {
+	schema.GroupVersionKind
+	Namespace string
+	Name      string
+	// UID is optional, it is used to tell apart objects that are deleted and recreated with the same name.
+	UID types.UID
+}
+
+// ReferenceOptions defines options needed to build a Reference from an object.
+type ReferenceOptions struct {
+	scheme *runtime.Scheme
+}
+
+// WithReferenceScheme sets the scheme used to look up the GroupVersionKind of typed objects whose TypeMeta is empty.
+// The default value is Kubernetes scheme.
+func WithReferenceScheme(scheme *runtime.Scheme) func(*ReferenceOptions) {
+	return func(o *ReferenceOptions) {
+		o.scheme = scheme
+	}
+}
+
+// ReferenceFromObject returns the Reference of the typed or unstructured object, including its UID.
+func ReferenceFromObject(obj runtime.Object, options ...func(*ReferenceOptions)) (Reference, error) {
+	opts := &ReferenceOptions{
+		scheme: clientgoscheme.Scheme,
+	}
+	for _, f := range options {
+		f(opts)
+	}
+
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return Reference{}, err
+	}
+	gvk, err := apiutil.GVKForObject(obj, opts.scheme)
+	if err != nil {
+		return Reference{}, err
+	}
+	return Reference{
+		GroupVersionKind: gvk,
+		Namespace:        accessor.GetNamespace(),
+		Name:             accessor.GetName(),
+		UID:              accessor.GetUID(),
+	}, nil
+}
+
+// ParseReference parses a reference formatted as [group/]version/Kind/[namespace/]name[@uid]. The kind is the first
+// segment that starts with an uppercase letter. The uid is separated by the last @ of the name segment, so a name
+// that contains @ (eg: a ClusterRole named alice@example.com) must be followed by a uid or an empty one, eg:
+// rbac.authorization.k8s.io/v1/ClusterRole/alice@example.com@ as String formats it.
+func ParseReference(s string) (Reference, error) {
+	ref := Reference{}
+	parts := strings.Split(s, "/")
+	last := parts[len(parts)-1]
+	if i := strings.LastIndex(last, "@"); i >= 0 {
+		ref.UID = types.UID(last[i+1:])
+		parts[len(parts)-1] = last[:i]
+		// the empty uid is only allowed to delimit a name that contains @
+		if ref.UID == "" && !strings.Contains(last[:i], "@") {
+			return Reference{}, fmt.Errorf("invalid reference %q: empty uid", s)
+		}
+	}
+
+	kindIndex := -1
+	for i, part := range parts {
+		if part != "" && unicode.IsUpper([]rune(part)[0]) {
+			kindIndex = i
+			break
+		}
+	}
+	if kindIndex < 1 || kindIndex > 2 {
+		return Reference{}, fmt.Errorf("invalid reference %q: expected [group/]version/Kind/[namespace/]name", s)
+	}
+	gv := strings.Join(parts[:kindIndex], "/")
+	rest := parts[kindIndex+1:]
+	switch len(rest) {
+	case 1:
+		ref.Name = rest[0]
+	case 2:
+		ref.Namespace, ref.Name = rest[0], rest[1]
+	default:
+		return Reference{}, fmt.Errorf("invalid reference %q: expected [group/]version/Kind/[namespace/]name", s)
+	}
+
+	groupVersion, err := schema.ParseGroupVersion(gv)
+	if err != nil {
+		return Reference{}, fmt.Errorf("invalid reference %q: %v", s, err)
+	}
+	if groupVersion.Version == "" || ref.Name == "" || (len(rest) == 2 && ref.Namespace == "") {
+		return Reference{}, fmt.Errorf("invalid reference %q: empty segment", s)
+	}
+	ref.GroupVersionKind = groupVersion.WithKind(parts[kindIndex])
+	return ref, nil
+}
+
+// String formats the reference as [group/]version/Kind/[namespace/]name[@uid], the @ is always written if the name
+// contains @ so that the name can be parsed back.
+func (r Reference) String() string {
+	var b strings.Builder
+	b.WriteString(r.GroupVersion().String())
+	b.WriteString("/")
+	b.WriteString(r.Kind)
+	b.WriteString("/")
+	if r.Namespace != "" {
+		b.WriteString(r.Namespace)
+		b.WriteString("/")
+	}
+	b.WriteString(r.Name)
+	if r.UID != "" || strings.Contains(r.Name, "@") {
+		b.WriteString("@")
+		b.WriteString(string(r.UID))
+	}
+	return b.String()
+}
+
+// NamespacedName returns the namespace and name of the referenced object.
+func (r Reference) NamespacedName() types.NamespacedName {
+	return types.NamespacedName{Namespace: r.Namespace, Name: r.Name}
+}
+
+// ObjectReference converts the reference to a corev1.ObjectReference, eg: for recording events.
+func (r Reference) ObjectReference() corev1.ObjectReference {
+	apiVersion, kind := r.ToAPIVersionAndKind()
+	return corev1.ObjectReference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  r.Namespace,
+		Name:       r.Name,
+		UID:        r.UID,
+	}
+}
@@ -0,0 +1,160 @@
+package object
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		name   string
+		ref    string
+		isErr  bool
+		wanted Reference
+	}{
+		{
+			name: "namespaced test",
+			ref:  "apps/v1/Deployment/default/web",
+			wanted: Reference{
+				GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+				Namespace:        "default",
+				Name:             "web",
+			},
+		},
+		{
+			name: "core group test",
+			ref:  "v1/Pod/default/web@1234",
+			wanted: Reference{
+				GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+				Namespace:        "default",
+				Name:             "web",
+				UID:              "1234",
+			},
+		},
+		{
+			name: "cluster-scoped test",
+			ref:  "rbac.authorization.k8s.io/v1/ClusterRole/admin",
+			wanted: Reference{
+				GroupVersionKind: schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+				Name:             "admin",
+			},
+		},
+		{
+			name: "at sign name test",
+			ref:  "rbac.authorization.k8s.io/v1/ClusterRole/alice@example.com@",
+			wanted: Reference{
+				GroupVersionKind: schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+				Name:             "alice@example.com",
+			},
+		},
+		{
+			name: "at sign name with uid test",
+			ref:  "rbac.authorization.k8s.io/v1/ClusterRole/alice@example.com@1234",
+			wanted: Reference{
+				GroupVersionKind: schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+				Name:             "alice@example.com",
+				UID:              "1234",
+			},
+		},
+		{
+			name:  "no kind test",
+			ref:   "apps/v1/deployment/default/web",
+			isErr: true,
+		},
+		{
+			name:  "too many segments test",
+			ref:   "v1/Pod/default/web/extra",
+			isErr: true,
+		},
+		{
+			name:  "empty name test",
+			ref:   "v1/Pod/default/",
+			isErr: true,
+		},
+		{
+			name:  "empty uid test",
+			ref:   "v1/Pod/default/web@",
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseReference(tt.ref)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s ParseReference() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			if got != tt.wanted {
+				t.Errorf("ParseReference() = %+v, want %+v", got, tt.wanted)
+			}
+			if got.String() != tt.ref {
+				t.Errorf("String() = %v, want %v", got.String(), tt.ref)
+			}
+		})
+	}
+}
+
+func TestReferenceFromObject(t *testing.T) {
+	tests := []struct {
+		name   string
+		obj    runtime.Object
+		isErr  bool
+		wanted string
+	}{
+		{
+			name: "typed test",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "1"},
+			},
+			wanted: "apps/v1/Deployment/default/web@1",
+		},
+		{
+			name: "unstructured test",
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Foo",
+				"metadata": map[string]interface{}{
+					"name": "foo",
+				},
+			}},
+			wanted: "example.com/v1/Foo/foo",
+		},
+		{
+			name:  "not object test",
+			obj:   &metav1.Status{},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReferenceFromObject(tt.obj)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s ReferenceFromObject() unexpected error: %v", tt.name, err)
+			}
+			if !tt.isErr && got.String() != tt.wanted {
+				t.Errorf("ReferenceFromObject() = %v, want %v", got.String(), tt.wanted)
+			}
+		})
+	}
+}
+
+func TestReferenceConversion(t *testing.T) {
+	ref, _ := ParseReference("apps/v1/Deployment/default/web@1")
+	wanted := corev1.ObjectReference{
+		APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web", UID: "1",
+	}
+	if got := ref.ObjectReference(); got != wanted {
+		t.Errorf("ObjectReference() = %+v, want %+v", got, wanted)
+	}
+	if got := ref.NamespacedName().String(); got != "default/web" {
+		t.Errorf("NamespacedName() = %v, want default/web", got)
+	}
+}
@@ -0,0 +1,159 @@
+package object
+
+import (
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// DefaultSanitizeFields are the server-populated fields removed from every object by Sanitize.
+var DefaultSanitizeFields = []string{
+	"metadata.uid",
+	"metadata.resourceVersion",
+	"metadata.managedFields",
+	"metadata.creationTimestamp",
+	"metadata.deletionTimestamp",
+	"metadata.deletionGracePeriodSeconds",
+	"metadata.generation",
+	"metadata.selfLink",
+	"metadata.ownerReferences",
+	"status",
+}
+
+// DefaultSanitizeKindFields are the cluster-specific fields removed from the objects of the given kinds by Sanitize.
+// The clusterIP fields of headless Services (clusterIP: None) are kept, since they are part of the Service spec.
+var DefaultSanitizeKindFields = map[schema.GroupKind][]string{
+	{Kind: "Service"}: {"spec.clusterIP", "spec.clusterIPs"},
+	{Kind: "Pod"}:     {"spec.nodeName"},
+}
+
+// SanitizeOptions defines options needed to sanitize an object.
+type SanitizeOptions struct {
+	defaultRules bool
+	keepStatus   bool
+	fields       []string
+	kindFields   map[schema.GroupKind][]string
+	annotations  []string
+	scheme       *runtime.Scheme
+}
+
+// WithDefaultRules sets whether to remove DefaultSanitizeFields and DefaultSanitizeKindFields.
+// The default value is true.
+func WithDefaultRules(enabled bool) func(*SanitizeOptions) {
+	return func(o *SanitizeOptions) {
+		o.defaultRules = enabled
+	}
+}
+
+// WithKeepStatus sets whether to keep the status of the object.
+// The default value is false.
+func WithKeepStatus(keep bool) func(*SanitizeOptions) {
+	return func(o *SanitizeOptions) {
+		o.keepStatus = keep
+	}
+}
+
+// WithSanitizeFields adds the fields (eg: spec.template.metadata.creationTimestamp) to remove from every object.
+func WithSanitizeFields(paths ...string) func(*SanitizeOptions) {
+	return func(o *SanitizeOptions) {
+		o.fields = append(o.fields, paths...)
+	}
+}
+
+// WithSanitizeKindFields adds the fields to remove from the objects of the given kind.
+func WithSanitizeKindFields(gk schema.GroupKind, paths ...string) func(*SanitizeOptions) {
+	return func(o *SanitizeOptions) {
+		o.kindFields[gk] = append(o.kindFields[gk], paths...)
+	}
+}
+
+// WithSanitizeAnnotations sets the annotations to remove from every object.
+// The default value is kubectl.kubernetes.io/last-applied-configuration.
+func WithSanitizeAnnotations(keys ...string) func(*SanitizeOptions) {
+	return func(o *SanitizeOptions) {
+		o.annotations = keys
+	}
+}
+
+// WithSanitizeScheme sets the scheme used to look up the kinds of typed objects.
+// The default value is Kubernetes scheme.
+func WithSanitizeScheme(scheme *runtime.Scheme) func(*SanitizeOptions) {
+	return func(o *SanitizeOptions) {
+		o.scheme = scheme
+	}
+}
+
+// Sanitize removes the server-populated and cluster-specific fields (uid, resourceVersion, managedFields, status,
+// Service clusterIPs, etc.) from the typed or unstructured object in place, so that it can be applied to another
+// cluster. The apiVersion and kind of typed objects are filled in from the scheme.
+func Sanitize(obj runtime.Object, options ...func(*SanitizeOptions)) error {
+	opts := &SanitizeOptions{
+		defaultRules: true,
+		kindFields:   make(map[schema.GroupKind][]string),
+		annotations:  []string{LastAppliedConfigAnnotation},
+		scheme:       clientgoscheme.Scheme,
+	}
+	for _, f := range options {
+		f(opts)
+	}
+
+	u, isUnstructured := obj.(*unstructured.Unstructured)
+	if !isUnstructured {
+		gvk, err := apiutil.GVKForObject(obj, opts.scheme)
+		if err != nil {
+			return err
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		u = &unstructured.Unstructured{Object: content}
+		u.SetGroupVersionKind(gvk)
+	}
+
+	gk := u.GroupVersionKind().GroupKind()
+	fields := append([]string{}, opts.fields...)
+	fields = append(fields, opts.kindFields[gk]...)
+	if opts.defaultRules {
+		fields = append(fields, DefaultSanitizeFields...)
+		fields = append(fields, DefaultSanitizeKindFields[gk]...)
+	}
+	headless := isHeadlessService(u)
+	for _, path := range fields {
+		if path == "status" && opts.keepStatus {
+			continue
+		}
+		if headless && (path == "spec.clusterIP" || path == "spec.clusterIPs") {
+			continue
+		}
+		unstructured.RemoveNestedField(u.Object, strings.Split(path, ".")...)
+	}
+	for _, key := range opts.annotations {
+		RemoveAnnotation(u, key)
+	}
+	if len(u.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(u.Object, "metadata", "annotations")
+	}
+
+	if isUnstructured {
+		return nil
+	}
+	value := reflect.ValueOf(obj).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj)
+}
+
+// isHeadlessService determines whether the object is a Service whose clusterIP is None.
+func isHeadlessService(u *unstructured.Unstructured) bool {
+	if u.GroupVersionKind().GroupKind() != (schema.GroupKind{Kind: "Service"}) {
+		return false
+	}
+	clusterIP, _, _ := unstructured.NestedString(u.Object, "spec", "clusterIP")
+	return clusterIP == corev1.ClusterIPNone
+}
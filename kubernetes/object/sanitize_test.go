@@ -0,0 +1,123 @@
+package object
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestSanitize(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "svc",
+			Namespace:         "default",
+			UID:               "1",
+			ResourceVersion:   "10",
+			Generation:        2,
+			CreationTimestamp: metav1.Now(),
+			ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			Annotations: map[string]string{
+				LastAppliedConfigAnnotation: "{}",
+			},
+			Labels: map[string]string{"app": "web"},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:  "10.0.0.1",
+			ClusterIPs: []string{"10.0.0.1"},
+			Ports:      []corev1.ServicePort{{Port: 80}},
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "1.1.1.1"}},
+			},
+		},
+	}
+
+	t.Run("typed test", func(t *testing.T) {
+		obj := svc.DeepCopy()
+		if err := Sanitize(obj); err != nil {
+			t.Fatalf("Sanitize() unexpected error: %v", err)
+		}
+		if obj.UID != "" || obj.ResourceVersion != "" || obj.Generation != 0 || !obj.CreationTimestamp.IsZero() ||
+			obj.ManagedFields != nil || obj.Annotations != nil {
+			t.Errorf("Sanitize() metadata = %+v", obj.ObjectMeta)
+		}
+		if obj.Spec.ClusterIP != "" || obj.Spec.ClusterIPs != nil || len(obj.Spec.Ports) != 1 {
+			t.Errorf("Sanitize() spec = %+v", obj.Spec)
+		}
+		if len(obj.Status.LoadBalancer.Ingress) != 0 {
+			t.Errorf("Sanitize() status = %+v", obj.Status)
+		}
+		if obj.Kind != "Service" || obj.APIVersion != "v1" || obj.Name != "svc" || obj.Labels["app"] != "web" {
+			t.Errorf("Sanitize() = %+v", obj)
+		}
+	})
+
+	t.Run("headless service test", func(t *testing.T) {
+		obj := svc.DeepCopy()
+		obj.Spec.ClusterIP = corev1.ClusterIPNone
+		obj.Spec.ClusterIPs = []string{corev1.ClusterIPNone}
+		if err := Sanitize(obj); err != nil {
+			t.Fatalf("Sanitize() unexpected error: %v", err)
+		}
+		if obj.Spec.ClusterIP != corev1.ClusterIPNone || len(obj.Spec.ClusterIPs) != 1 ||
+			obj.Spec.ClusterIPs[0] != corev1.ClusterIPNone {
+			t.Errorf("Sanitize() spec = %+v", obj.Spec)
+		}
+		if obj.UID != "" || obj.ResourceVersion != "" {
+			t.Errorf("Sanitize() metadata = %+v", obj.ObjectMeta)
+		}
+	})
+
+	t.Run("options test", func(t *testing.T) {
+		obj := svc.DeepCopy()
+		if err := Sanitize(obj,
+			WithDefaultRules(false),
+			WithSanitizeFields("metadata.uid", "status"),
+			WithKeepStatus(true),
+			WithSanitizeKindFields(schema.GroupKind{Kind: "Service"}, "spec.ports"),
+			WithSanitizeAnnotations(),
+		); err != nil {
+			t.Fatalf("Sanitize() unexpected error: %v", err)
+		}
+		if obj.UID != "" || obj.ResourceVersion != "10" || obj.Annotations[LastAppliedConfigAnnotation] != "{}" {
+			t.Errorf("Sanitize() metadata = %+v", obj.ObjectMeta)
+		}
+		if obj.Spec.ClusterIP != "10.0.0.1" || obj.Spec.Ports != nil {
+			t.Errorf("Sanitize() spec = %+v", obj.Spec)
+		}
+		if len(obj.Status.LoadBalancer.Ingress) != 1 {
+			t.Errorf("Sanitize() status = %+v", obj.Status)
+		}
+	})
+
+	t.Run("unstructured test", func(t *testing.T) {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Foo",
+			"metadata": map[string]interface{}{
+				"name":            "foo",
+				"uid":             "1",
+				"resourceVersion": "10",
+			},
+			"spec":   map[string]interface{}{"aa": "bb"},
+			"status": map[string]interface{}{"cc": "dd"},
+		}}
+		if err := Sanitize(obj); err != nil {
+			t.Fatalf("Sanitize() unexpected error: %v", err)
+		}
+		if obj.GetUID() != "" || obj.GetResourceVersion() != "" || obj.Object["status"] != nil || obj.Object["spec"] == nil {
+			t.Errorf("Sanitize() = %v", obj.Object)
+		}
+	})
+
+	t.Run("unregistered type test", func(t *testing.T) {
+		if err := Sanitize(svc.DeepCopy(), WithSanitizeScheme(runtime.NewScheme())); err == nil {
+			t.Errorf("Sanitize() expected an error for an unregistered type")
+		}
+	})
+}
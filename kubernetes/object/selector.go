@@ -0,0 +1,158 @@
+package object
+
+import (
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// MatchesLabelSelector determines whether the object's labels match the label selector.
+// Following the Kubernetes semantics, a nil selector matches nothing and an empty one matches everything.
+func MatchesLabelSelector(obj metav1.Object, selector *metav1.LabelSelector) (bool, error) {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(labels.Set(obj.GetLabels())), nil
+}
+
+// MatchesSelectorString determines whether the object's labels match the selector string (eg: app=web,tier!=db).
+func MatchesSelectorString(obj metav1.Object, selector string) (bool, error) {
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(labels.Set(obj.GetLabels())), nil
+}
+
+// SelectorBuilder builds label selectors fluently.
+//
+// eg:
+//
+//	selector := NewSelectorBuilder().
+//		WithLabels(map[string]string{"app": "web"}).
+//		WithIn("tier", "frontend", "backend").
+//		WithExists("team").
+//		Build()
+type SelectorBuilder struct {
+	matchLabels      map[string]string
+	matchExpressions []metav1.LabelSelectorRequirement
+}
+
+// NewSelectorBuilder returns a new SelectorBuilder that builds an empty selector which matches everything.
+func NewSelectorBuilder() *SelectorBuilder {
+	return &SelectorBuilder{
+		matchLabels: make(map[string]string),
+	}
+}
+
+// WithLabel requires the label to have the value.
+func (b *SelectorBuilder) WithLabel(key, value string) *SelectorBuilder {
+	b.matchLabels[key] = value
+	return b
+}
+
+// WithLabels requires all the labels to have the values.
+func (b *SelectorBuilder) WithLabels(matchLabels map[string]string) *SelectorBuilder {
+	for k, v := range matchLabels {
+		b.matchLabels[k] = v
+	}
+	return b
+}
+
+// WithIn requires the label to have one of the values.
+func (b *SelectorBuilder) WithIn(key string, values ...string) *SelectorBuilder {
+	return b.withExpression(key, metav1.LabelSelectorOpIn, values)
+}
+
+// WithNotIn requires the label to be absent or have none of the values.
+func (b *SelectorBuilder) WithNotIn(key string, values ...string) *SelectorBuilder {
+	return b.withExpression(key, metav1.LabelSelectorOpNotIn, values)
+}
+
+// WithExists requires the label to be present.
+func (b *SelectorBuilder) WithExists(key string) *SelectorBuilder {
+	return b.withExpression(key, metav1.LabelSelectorOpExists, nil)
+}
+
+// WithNotExists requires the label to be absent.
+func (b *SelectorBuilder) WithNotExists(key string) *SelectorBuilder {
+	return b.withExpression(key, metav1.LabelSelectorOpDoesNotExist, nil)
+}
+
+func (b *SelectorBuilder) withExpression(
+	key string, operator metav1.LabelSelectorOperator, values []string,
+) *SelectorBuilder {
+	b.matchExpressions = append(b.matchExpressions, metav1.LabelSelectorRequirement{
+		Key:      key,
+		Operator: operator,
+		Values:   values,
+	})
+	return b
+}
+
+// Build returns the label selector.
+func (b *SelectorBuilder) Build() *metav1.LabelSelector {
+	selector := &metav1.LabelSelector{}
+	if len(b.matchLabels) > 0 {
+		selector.MatchLabels = make(map[string]string, len(b.matchLabels))
+		for k, v := range b.matchLabels {
+			selector.MatchLabels[k] = v
+		}
+	}
+	if len(b.matchExpressions) > 0 {
+		selector.MatchExpressions = make([]metav1.LabelSelectorRequirement, len(b.matchExpressions))
+		for i, r := range b.matchExpressions {
+			r.DeepCopyInto(&selector.MatchExpressions[i])
+		}
+	}
+	return selector
+}
+
+// Selector returns the labels.Selector that can be used in list options.
+func (b *SelectorBuilder) Selector() (labels.Selector, error) {
+	return metav1.LabelSelectorAsSelector(b.Build())
+}
+
+// IntersectSelectors returns a label selector that matches the objects matched by both selectors.
+// The result is nil (matches nothing) if any of them is nil, and an error is returned if they require different
+// values for the same label, since no object can match both of them.
+func IntersectSelectors(a, b *metav1.LabelSelector) (*metav1.LabelSelector, error) {
+	if a == nil || b == nil {
+		return nil, nil
+	}
+
+	result := &metav1.LabelSelector{}
+	for _, s := range []*metav1.LabelSelector{a, b} {
+		for k, v := range s.MatchLabels {
+			if existing, ok := result.MatchLabels[k]; ok && existing != v {
+				return nil, fmt.Errorf("selectors require different values %q and %q for label %q", existing, v, k)
+			}
+			if result.MatchLabels == nil {
+				result.MatchLabels = make(map[string]string)
+			}
+			result.MatchLabels[k] = v
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, s := range []*metav1.LabelSelector{a, b} {
+		for _, r := range s.MatchExpressions {
+			values := append([]string{}, r.Values...)
+			sort.Strings(values)
+			key := fmt.Sprintf("%s %s %v", r.Key, r.Operator, values)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result.MatchExpressions = append(result.MatchExpressions, *r.DeepCopy())
+		}
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,173 @@
+package object
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var selectorTestObj = &corev1.Pod{
+	ObjectMeta: metav1.ObjectMeta{
+		Labels: map[string]string{
+			"app":  "web",
+			"tier": "frontend",
+		},
+	},
+}
+
+func TestMatchesLabelSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector *metav1.LabelSelector
+		isErr    bool
+		wanted   bool
+	}{
+		{
+			name:     "match test",
+			selector: NewSelectorBuilder().WithLabel("app", "web").WithIn("tier", "frontend", "backend").Build(),
+			wanted:   true,
+		},
+		{
+			name:     "not match test",
+			selector: NewSelectorBuilder().WithLabel("app", "web").WithNotExists("tier").Build(),
+			wanted:   false,
+		},
+		{
+			name:     "empty test",
+			selector: &metav1.LabelSelector{},
+			wanted:   true,
+		},
+		{
+			name:     "nil test",
+			selector: nil,
+			wanted:   false,
+		},
+		{
+			name:     "invalid test",
+			selector: NewSelectorBuilder().WithIn("app").Build(),
+			isErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchesLabelSelector(selectorTestObj, tt.selector)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s MatchesLabelSelector() unexpected error: %v", tt.name, err)
+			}
+			if got != tt.wanted {
+				t.Errorf("MatchesLabelSelector() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestMatchesSelectorString(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		isErr    bool
+		wanted   bool
+	}{
+		{
+			name:     "match test",
+			selector: "app=web,tier in (frontend,backend)",
+			wanted:   true,
+		},
+		{
+			name:     "not match test",
+			selector: "app!=web",
+			wanted:   false,
+		},
+		{
+			name:     "invalid test",
+			selector: "tier in (frontend",
+			isErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchesSelectorString(selectorTestObj, tt.selector)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s MatchesSelectorString() unexpected error: %v", tt.name, err)
+			}
+			if got != tt.wanted {
+				t.Errorf("MatchesSelectorString() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestSelectorBuilder(t *testing.T) {
+	selector, err := NewSelectorBuilder().
+		WithLabels(map[string]string{"app": "web"}).
+		WithNotIn("tier", "db").
+		WithExists("team").
+		Selector()
+	if err != nil {
+		t.Fatalf("Selector() unexpected error: %v", err)
+	}
+	wanted := "app=web,team,tier notin (db)"
+	if selector.String() != wanted {
+		t.Errorf("Selector() = %v, want %v", selector.String(), wanted)
+	}
+}
+
+func TestIntersectSelectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    *metav1.LabelSelector
+		isErr   bool
+		isNil   bool
+		matches bool
+		wanted  int
+	}{
+		{
+			name:    "match test",
+			a:       NewSelectorBuilder().WithLabel("app", "web").WithExists("tier").Build(),
+			b:       NewSelectorBuilder().WithIn("tier", "frontend").WithExists("tier").Build(),
+			matches: true,
+			wanted:  2,
+		},
+		{
+			name:    "not match test",
+			a:       NewSelectorBuilder().WithLabel("app", "web").Build(),
+			b:       NewSelectorBuilder().WithNotIn("tier", "frontend").Build(),
+			matches: false,
+			wanted:  1,
+		},
+		{
+			name:  "conflict test",
+			a:     NewSelectorBuilder().WithLabel("app", "web").Build(),
+			b:     NewSelectorBuilder().WithLabel("app", "db").Build(),
+			isErr: true,
+			isNil: true,
+		},
+		{
+			name:  "nil test",
+			a:     NewSelectorBuilder().WithLabel("app", "web").Build(),
+			b:     nil,
+			isNil: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IntersectSelectors(tt.a, tt.b)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s IntersectSelectors() unexpected error: %v", tt.name, err)
+			}
+			if tt.isNil != (got == nil) {
+				t.Fatalf("IntersectSelectors() = %v, want nil %v", got, tt.isNil)
+			}
+			if got == nil {
+				return
+			}
+			if len(got.MatchExpressions) != tt.wanted {
+				t.Errorf("IntersectSelectors() = %v, want %d expressions", got.MatchExpressions, tt.wanted)
+			}
+			if matches, _ := MatchesLabelSelector(selectorTestObj, got); matches != tt.matches {
+				t.Errorf("IntersectSelectors() matches = %v, want %v", matches, tt.matches)
+			}
+		})
+	}
+}
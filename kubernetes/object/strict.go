@@ -0,0 +1,272 @@
+package object
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+
+	yamlv3 "gopkg.in/yaml.v3"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kjson "sigs.k8s.io/json"
+	"sigs.k8s.io/yaml"
+)
+
+var unknownFieldPattern = regexp.MustCompile(`^unknown field "(.*)"$`)
+
+// StrictDecodeOptions defines options needed to decode manifests strictly.
+type StrictDecodeOptions struct {
+	crds        []*apiextensionsv1.CustomResourceDefinition
+	crdPatterns []string
+}
+
+// WithCRDs adds the CRDs whose OpenAPI schemas are used to validate the custom resources.
+func WithCRDs(crds ...*apiextensionsv1.CustomResourceDefinition) func(*StrictDecodeOptions) {
+	return func(o *StrictDecodeOptions) {
+		o.crds = append(o.crds, crds...)
+	}
+}
+
+// WithCRDFiles adds the files and directory trees (glob patterns, see LoadManifestsFromPaths) to load the CRDs from,
+// the other objects in the files are ignored.
+func WithCRDFiles(patterns ...string) func(*StrictDecodeOptions) {
+	return func(o *StrictDecodeOptions) {
+		o.crdPatterns = append(o.crdPatterns, patterns...)
+	}
+}
+
+// StrictDecoder decodes manifests into typed objects and rejects the unknown and duplicate fields, so that typos
+// (eg: replcas) are caught before the objects are applied.
+type StrictDecoder struct {
+	scheme     *runtime.Scheme
+	serializer *json.Serializer
+	// schemas are the structural schemas of the custom resources
+	schemas map[schema.GroupVersionKind]*structuralschema.Structural
+}
+
+// NewStrictDecoder returns a new StrictDecoder that decodes the kinds of the scheme into typed objects, and the
+// custom resources of the CRDs into unstructured objects validated against the CRD schemas.
+func NewStrictDecoder(scheme *runtime.Scheme, options ...func(*StrictDecodeOptions)) (*StrictDecoder, error) {
+	opts := &StrictDecodeOptions{}
+	for _, f := range options {
+		f(opts)
+	}
+
+	crds := append([]*apiextensionsv1.CustomResourceDefinition{}, opts.crds...)
+	if len(opts.crdPatterns) > 0 {
+		manifests, err := LoadManifestsFromPaths(opts.crdPatterns)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range manifests {
+			if m.Object.GroupVersionKind() != apiextensionsv1.SchemeGroupVersion.WithKind(CustomResourceDefinitionKind) {
+				continue
+			}
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			if err = runtime.DefaultUnstructuredConverter.FromUnstructured(m.Object.Object, crd); err != nil {
+				return nil, fmt.Errorf("%s: %v", m.Source, err)
+			}
+			crds = append(crds, crd)
+		}
+	}
+
+	decoder := &StrictDecoder{
+		scheme: scheme,
+		serializer: json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{
+			Yaml:   true,
+			Strict: true,
+		}),
+		schemas: make(map[schema.GroupVersionKind]*structuralschema.Structural),
+	}
+	for _, crd := range crds {
+		for _, version := range crd.Spec.Versions {
+			if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			props := &apiextensions.JSONSchemaProps{}
+			err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(
+				version.Schema.OpenAPIV3Schema, props, nil,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("CRD %s version %s: %v", crd.Name, version.Name, err)
+			}
+			structural, err := structuralschema.NewStructural(props)
+			if err != nil {
+				return nil, fmt.Errorf("CRD %s version %s: %v", crd.Name, version.Name, err)
+			}
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}
+			decoder.schemas[gvk] = structural
+		}
+	}
+	return decoder, nil
+}
+
+// Decode decodes all the YAML or JSON manifests in the stream, the kinds of the scheme are decoded into typed
+// objects and the custom resources into unstructured objects. All the unknown and duplicate fields of all the
+// documents are reported with their JSON paths, each document's errors are returned as a *DecodeError.
+//
+// eg:
+//
+//	decoder, err := NewStrictDecoder(clientgoscheme.Scheme, WithCRDFiles("config/crd/bases"))
+//	if err != nil {
+//		return err
+//	}
+//	objs, err := decoder.Decode(file)
+//	// document 0 (line 1): spec.replcas: Forbidden: unknown field
+func (d *StrictDecoder) Decode(r io.Reader) ([]runtime.Object, error) {
+	reader := NewManifestDecoder(r)
+	objs := make([]runtime.Object, 0)
+	var errs []error
+	for {
+		doc, err := reader.nextDocument()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// the rest of the stream cannot be read
+			errs = append(errs, err)
+			break
+		}
+		obj, err := d.decodeDocument(doc)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	return objs, nil
+}
+
+// decodeDocument decodes the document strictly, nil is returned for an empty document.
+func (d *StrictDecoder) decodeDocument(doc *rawDocument) (runtime.Object, error) {
+	content, err := doc.unmarshal()
+	if err != nil || len(content) == 0 {
+		return nil, err
+	}
+	documentError := func(err error) error {
+		return &DecodeError{Index: doc.index, Line: doc.line, Err: err}
+	}
+
+	errs := duplicateFields(doc.data)
+	u := &unstructured.Unstructured{Object: content}
+	gvk := u.GroupVersionKind()
+	var obj runtime.Object
+	if structural, ok := d.schemas[gvk]; ok {
+		errs = append(errs, customResourceUnknownFields(u, structural)...)
+		obj = u
+	} else {
+		obj, _, err = d.serializer.Decode(doc.data, nil, nil)
+		if strictErr, ok := runtime.AsStrictDecodingError(err); ok {
+			errs = append(errs, unknownFields(strictErr.Errors(), nil)...)
+		} else if err != nil {
+			return nil, documentError(err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, documentError(errs.ToAggregate())
+	}
+	return obj, nil
+}
+
+// customResourceUnknownFields returns the fields of the custom resource that are not in its schema.
+func customResourceUnknownFields(u *unstructured.Unstructured, structural *structuralschema.Structural) field.ErrorList {
+	var errs field.ErrorList
+	if metadata, ok := u.Object["metadata"]; ok {
+		data, err := yaml.Marshal(metadata)
+		if err != nil {
+			return field.ErrorList{field.InternalError(field.NewPath("metadata"), err)}
+		}
+		data, err = yaml.YAMLToJSON(data)
+		if err != nil {
+			return field.ErrorList{field.InternalError(field.NewPath("metadata"), err)}
+		}
+		strictErrs, err := kjson.UnmarshalStrict(data, &metav1.ObjectMeta{}, kjson.DisallowUnknownFields)
+		if err != nil {
+			return field.ErrorList{field.Invalid(field.NewPath("metadata"), metadata, err.Error())}
+		}
+		errs = append(errs, unknownFields(strictErrs, field.NewPath("metadata"))...)
+	}
+
+	paths := pruning.PruneWithOptions(u.DeepCopy().Object, structural, true, structuralschema.UnknownFieldPathOptions{
+		TrackUnknownFieldPaths: true,
+	})
+	for _, path := range paths {
+		errs = append(errs, field.Forbidden(field.NewPath(path), "unknown field"))
+	}
+	return errs
+}
+
+// unknownFields converts the unknown field errors of the strict decoding into field errors, the duplicate field
+// errors are ignored since they are reported by duplicateFields.
+func unknownFields(strictErrs []error, parent *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, err := range strictErrs {
+		m := unknownFieldPattern.FindStringSubmatch(err.Error())
+		if m == nil {
+			continue
+		}
+		path := field.NewPath(m[1])
+		if parent != nil {
+			path = parent.Child(m[1])
+		}
+		errs = append(errs, field.Forbidden(path, "unknown field"))
+	}
+	return errs
+}
+
+// duplicateFields returns the duplicate keys of the maps in the YAML or JSON document.
+func duplicateFields(data []byte) field.ErrorList {
+	node := &yamlv3.Node{}
+	if err := yamlv3.NewDecoder(bytes.NewReader(data)).Decode(node); err != nil {
+		return nil
+	}
+	var errs field.ErrorList
+	var walk func(node *yamlv3.Node, path *field.Path)
+	walk = func(node *yamlv3.Node, path *field.Path) {
+		switch node.Kind {
+		case yamlv3.DocumentNode:
+			for _, child := range node.Content {
+				walk(child, path)
+			}
+		case yamlv3.MappingNode:
+			seen := make(map[string]bool)
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i].Value
+				var childPath *field.Path
+				if path == nil {
+					childPath = field.NewPath(key)
+				} else {
+					childPath = path.Child(key)
+				}
+				if seen[key] {
+					errs = append(errs, field.Duplicate(childPath, field.OmitValueType{}))
+				}
+				seen[key] = true
+				walk(node.Content[i+1], childPath)
+			}
+		case yamlv3.SequenceNode:
+			for i, child := range node.Content {
+				walk(child, path.Index(i))
+			}
+		}
+	}
+	walk(node, nil)
+	return errs
+}
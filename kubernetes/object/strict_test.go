@@ -0,0 +1,149 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+const strictCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: foos.example.com
+spec:
+  group: example.com
+  scope: Namespaced
+  names:
+    kind: Foo
+    plural: foos
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              size:
+                type: integer
+`
+
+func TestStrictDecoder(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "crd.yaml"), []byte(strictCRD), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	decoder, err := NewStrictDecoder(clientgoscheme.Scheme, WithCRDFiles(dir))
+	if err != nil {
+		t.Fatalf("NewStrictDecoder() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		isErr  bool
+		errors []string
+		kinds  []string
+	}{
+		{
+			name: "valid test",
+			input: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 1
+---
+apiVersion: example.com/v1
+kind: Foo
+metadata:
+  name: foo
+spec:
+  size: 1
+`,
+			kinds: []string{"*v1.Deployment", "*unstructured.Unstructured"},
+		},
+		{
+			name: "unknown and duplicate fields test",
+			input: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replcas: 1
+  template:
+    spec:
+      containers:
+      - name: web
+        imagee: web:v1
+---
+apiVersion: example.com/v1
+kind: Foo
+metadata:
+  name: foo
+  labelz: {}
+spec:
+  size: 1
+  size: 2
+  color: red
+`,
+			isErr: true,
+			errors: []string{
+				"document 0 (line 1): [spec.replcas: Forbidden: unknown field, " +
+					"spec.template.spec.containers[0].imagee: Forbidden: unknown field]",
+				"document 1 (line 13): [spec.size: Duplicate value, metadata.labelz: Forbidden: unknown field, " +
+					"spec.color: Forbidden: unknown field]",
+			},
+		},
+		{
+			name: "json duplicate test",
+			input: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a", "name": "b"}}
+`,
+			isErr:  true,
+			errors: []string{"document 0 (line 1): metadata.name: Duplicate value"},
+		},
+		{
+			name:   "unknown kind test",
+			input:  "apiVersion: example.com/v1\nkind: Bar\n",
+			isErr:  true,
+			errors: []string{`document 0 (line 1): no kind "Bar" is registered for version "example.com/v1"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs, err := decoder.Decode(strings.NewReader(tt.input))
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s Decode() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				var aggregate utilerrors.Aggregate
+				if !errors.As(err, &aggregate) || len(aggregate.Errors()) != len(tt.errors) {
+					t.Fatalf("Decode() error = %v, want %d errors", err, len(tt.errors))
+				}
+				for i, e := range aggregate.Errors() {
+					if !strings.HasPrefix(e.Error(), tt.errors[i]) {
+						t.Errorf("Decode() error = %v, want %v", e, tt.errors[i])
+					}
+				}
+				return
+			}
+			kinds := make([]string, 0, len(objs))
+			for _, obj := range objs {
+				kinds = append(kinds, fmt.Sprintf("%T", obj))
+			}
+			if !reflect.DeepEqual(kinds, tt.kinds) {
+				t.Errorf("Decode() = %v, want %v", kinds, tt.kinds)
+			}
+		})
+	}
+}
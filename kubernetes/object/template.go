@@ -0,0 +1,246 @@
+package object
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/iawia002/lia/hash"
+)
+
+// TemplateFuncs returns the functions available in the manifest templates:
+//
+//   - quote, squote: wrap the values in double (escaped) or single quotes, eg: {{ .name | quote }}
+//   - indent, nindent: indent every line of the string by n spaces, nindent also adds a leading newline
+//   - toYaml, toJson: serialise the value, eg: {{ .labels | toYaml | nindent 4 }}
+//   - default: return the default value if the value is empty or missing, eg: {{ .replicas | default 1 }}
+//   - required: fail the rendering with the message if the value is empty or missing
+//   - b64enc, b64dec: base64 encode or decode the string
+//   - trim, upper, lower: the strings functions of the same names
+//   - md5sum, sha1sum, sha1short, sha256sum, fnv32, fnv64, fnv128: the hash functions of the hash package
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"quote":     quote,
+		"squote":    squote,
+		"indent":    indent,
+		"nindent":   nindent,
+		"toYaml":    toYAML,
+		"toJson":    toJSON,
+		"default":   defaultValue,
+		"required":  required,
+		"b64enc":    base64Encode,
+		"b64dec":    base64Decode,
+		"trim":      strings.TrimSpace,
+		"upper":     strings.ToUpper,
+		"lower":     strings.ToLower,
+		"md5sum":    hash.MD5,
+		"sha1sum":   hash.SHA1,
+		"sha1short": hash.SHA1Short,
+		"sha256sum": hash.SHA256,
+		"fnv32":     hash.FNV32,
+		"fnv64":     hash.FNV64,
+		"fnv128":    hash.FNV128,
+	}
+}
+
+// RenderOptions defines options needed to render manifest templates.
+type RenderOptions struct {
+	funcs           template.FuncMap
+	missingKeyError bool
+}
+
+// WithTemplateFuncs adds the functions to the template, they override the TemplateFuncs of the same names.
+func WithTemplateFuncs(funcs template.FuncMap) func(*RenderOptions) {
+	return func(o *RenderOptions) {
+		for k, v := range funcs {
+			o.funcs[k] = v
+		}
+	}
+}
+
+// WithMissingKeyError sets whether to fail the rendering when a map value used by the template has no such key,
+// otherwise the missing values are passed to the functions as nil and rendered as empty strings like Helm. Note that
+// the missing keys fail the rendering before default and required are called when it is enabled.
+// The default value is false.
+func WithMissingKeyError(enabled bool) func(*RenderOptions) {
+	return func(o *RenderOptions) {
+		o.missingKeyError = enabled
+	}
+}
+
+// RenderTemplate executes the text/template with the values and returns the rendered content. The parsing and
+// execution errors point to the template line numbers (eg: template: deployment.yaml:12:20: ...).
+func RenderTemplate(name string, text []byte, values interface{}, options ...func(*RenderOptions)) ([]byte, error) {
+	opts := &RenderOptions{
+		funcs: TemplateFuncs(),
+	}
+	for _, f := range options {
+		f(opts)
+	}
+
+	tmpl := template.New(name).Funcs(opts.funcs).Option("missingkey=zero")
+	if opts.missingKeyError {
+		tmpl = tmpl.Option("missingkey=error")
+	}
+	tmpl, err := tmpl.Parse(string(text))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, values); err != nil {
+		return nil, err
+	}
+	if !opts.missingKeyError {
+		// the missing values of the maps are rendered as <no value>
+		return bytes.ReplaceAll(buf.Bytes(), []byte("<no value>"), nil), nil
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderManifests renders the manifest template with the values and decodes the result into unstructured objects,
+// the list objects are expanded into their items. Note that the decoding errors point to the line numbers of the
+// rendered content, not the template, so the offending rendered lines are included in the error.
+//
+// eg:
+//
+//	//go:embed deployment.yaml
+//	var deploymentTemplate []byte
+//
+//	objs, err := RenderManifests("deployment.yaml", deploymentTemplate, map[string]interface{}{
+//		"name":     "web",
+//		"replicas": 3,
+//	})
+func RenderManifests(
+	name string, text []byte, values interface{}, options ...func(*RenderOptions),
+) ([]*unstructured.Unstructured, error) {
+	content, err := RenderTemplate(name, text, values, options...)
+	if err != nil {
+		return nil, err
+	}
+	objs, err := LoadManifests(bytes.NewReader(content))
+	if err != nil {
+		var decodeErr *DecodeError
+		if errors.As(err, &decodeErr) {
+			return nil, fmt.Errorf("%s: rendered manifests: %w\n%s", name, err, renderedSnippet(content, decodeErr.Line))
+		}
+		return nil, fmt.Errorf("%s: rendered manifests: %w", name, err)
+	}
+	return objs, nil
+}
+
+// renderedSnippet returns the rendered lines around the 1-based line, prefixed with their line numbers, eg:
+//
+//	  6 | data:
+//	> 7 |   key: [
+//	  8 |
+func renderedSnippet(content []byte, line int) string {
+	lines := strings.Split(string(content), "\n")
+	start, end := max(line-2, 1), min(line+1, len(lines))
+	width := len(strconv.Itoa(end))
+	var b strings.Builder
+	for i := start; i <= end; i++ {
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "%s %*d | %s\n", marker, width, i, lines[i-1])
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func quote(values ...interface{}) string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != nil {
+			result = append(result, fmt.Sprintf("%q", fmt.Sprint(v)))
+		}
+	}
+	return strings.Join(result, " ")
+}
+
+func squote(values ...interface{}) string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != nil {
+			result = append(result, fmt.Sprintf("'%v'", v))
+		}
+	}
+	return strings.Join(result, " ")
+}
+
+func indent(spaces int, text string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(text, "\n", "\n"+pad)
+}
+
+func nindent(spaces int, text string) string {
+	return "\n" + indent(spaces, text)
+}
+
+func toYAML(v interface{}) (string, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// defaultValue returns the default value if the value is missing or empty, the value is the last argument so that it
+// can be piped.
+func defaultValue(defaultVal interface{}, values ...interface{}) interface{} {
+	if len(values) == 0 || isEmptyValue(values[0]) {
+		return defaultVal
+	}
+	return values[0]
+}
+
+func required(message string, v interface{}) (interface{}, error) {
+	if isEmptyValue(v) {
+		return nil, errors.New(message)
+	}
+	return v, nil
+}
+
+func base64Encode(text string) string {
+	return base64.StdEncoding.EncodeToString([]byte(text))
+}
+
+func base64Decode(text string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func isEmptyValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return value.IsNil()
+	default:
+		return value.IsZero()
+	}
+}
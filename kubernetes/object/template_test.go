@@ -0,0 +1,144 @@
+package object
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	values := map[string]interface{}{
+		"name":   "web",
+		"empty":  "",
+		"labels": map[string]string{"app": "web", "tier": "frontend"},
+	}
+	tests := []struct {
+		name    string
+		text    string
+		options []func(*RenderOptions)
+		isErr   bool
+		wanted  string
+	}{
+		{
+			name:   "quote test",
+			text:   `{{ .name | quote }} {{ .name | squote }}`,
+			wanted: `"web" 'web'`,
+		},
+		{
+			name:   "toYaml test",
+			text:   "labels:{{ .labels | toYaml | nindent 2 }}",
+			wanted: "labels:\n  app: web\n  tier: frontend",
+		},
+		{
+			name:   "toJson test",
+			text:   `{{ .labels | toJson }}`,
+			wanted: `{"app":"web","tier":"frontend"}`,
+		},
+		{
+			name:   "default test",
+			text:   `{{ .empty | default "fallback" }} {{ .name | default "fallback" }}`,
+			wanted: "fallback web",
+		},
+		{
+			name:   "default missing key test",
+			text:   `{{ .replicas | default 1 }} {{ .labels.missing | default "none" }}`,
+			wanted: "1 none",
+		},
+		{
+			name:   "b64enc test",
+			text:   `{{ .name | b64enc }} {{ .name | b64enc | b64dec }}`,
+			wanted: "d2Vi web",
+		},
+		{
+			name:   "hash test",
+			text:   `{{ .name | sha1short }}`,
+			wanted: "ca84d1",
+		},
+		{
+			name: "custom funcs test",
+			text: `{{ .name | double }}`,
+			options: []func(*RenderOptions){WithTemplateFuncs(template.FuncMap{
+				"double": func(s string) string { return s + s },
+			})},
+			wanted: "webweb",
+		},
+		{
+			name:  "required test",
+			text:  `{{ required "empty is required" .empty }}`,
+			isErr: true,
+		},
+		{
+			name:  "required missing key test",
+			text:  `{{ required "missing is required" .missing }}`,
+			isErr: true,
+		},
+		{
+			name:   "missing key test",
+			text:   `[{{ .missing }}]`,
+			wanted: "[]",
+		},
+		{
+			name:    "missing key error test",
+			text:    `{{ .missing }}`,
+			options: []func(*RenderOptions){WithMissingKeyError(true)},
+			isErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := RenderTemplate("test", []byte(tt.text), values, tt.options...)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s RenderTemplate() unexpected error: %v", tt.name, err)
+			}
+			if string(data) != tt.wanted {
+				t.Errorf("RenderTemplate() = %q, want %q", data, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestRenderManifests(t *testing.T) {
+	text := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .name }}
+  labels:{{ .labels | toYaml | nindent 4 }}
+data:
+  checksum: {{ .name | sha256sum | quote }}
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: {{ .name }}
+data:
+  password: {{ .missing }}
+`
+	values := map[string]interface{}{
+		"name":   "web",
+		"labels": map[string]string{"app": "web"},
+	}
+
+	_, err := RenderManifests("web.yaml", []byte(text), values, WithMissingKeyError(true))
+	if err == nil || !strings.Contains(err.Error(), "web.yaml:14:") {
+		t.Errorf("RenderManifests() error = %v, want an error at line 14", err)
+	}
+
+	values["missing"] = "cGFzc3dvcmQ="
+	objs, err := RenderManifests("web.yaml", []byte(text), values)
+	if err != nil {
+		t.Fatalf("RenderManifests() unexpected error: %v", err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("RenderManifests() = %d objects, want 2", len(objs))
+	}
+	if got := objs[0].GetLabels()["app"]; got != "web" {
+		t.Errorf("RenderManifests() label app = %s, want web", got)
+	}
+
+	// the labels are rendered into 2 lines, so the template line 14 is the rendered line 15
+	values["missing"] = "[invalid"
+	_, err = RenderManifests("web.yaml", []byte(text), values)
+	if err == nil || !strings.Contains(err.Error(), "> 15 |   password: [invalid") {
+		t.Errorf("RenderManifests() error = %v, want the rendered line 15", err)
+	}
+}
@@ -0,0 +1,211 @@
+package object
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ClusterScopedKinds are the well-known cluster-scoped kinds that are not moved to a namespace by SetNamespace.
+var ClusterScopedKinds = sets.New[schema.GroupKind](
+	schema.GroupKind{Kind: "Namespace"},
+	schema.GroupKind{Kind: "Node"},
+	schema.GroupKind{Kind: "PersistentVolume"},
+	schema.GroupKind{Kind: "ComponentStatus"},
+	schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"},
+	schema.GroupKind{Group: "apiregistration.k8s.io", Kind: "APIService"},
+	schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+	schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "StorageClass"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "CSIDriver"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "CSINode"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "VolumeAttachment"},
+	schema.GroupKind{Group: "scheduling.k8s.io", Kind: "PriorityClass"},
+	schema.GroupKind{Group: "networking.k8s.io", Kind: "IngressClass"},
+	schema.GroupKind{Group: "node.k8s.io", Kind: "RuntimeClass"},
+	schema.GroupKind{Group: "policy", Kind: "PodSecurityPolicy"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicy"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicyBinding"},
+	schema.GroupKind{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"},
+	schema.GroupKind{Group: "flowcontrol.apiserver.k8s.io", Kind: "FlowSchema"},
+	schema.GroupKind{Group: "flowcontrol.apiserver.k8s.io", Kind: "PriorityLevelConfiguration"},
+)
+
+// podTemplatePaths are the paths of the pod templates in the workload objects.
+var podTemplatePaths = [][]string{
+	{"spec", "template"},
+	{"spec", "jobTemplate", "spec", "template"},
+}
+
+// podSpecPaths are the paths of the pod specs in the pod and workload objects.
+var podSpecPaths = [][]string{
+	{"spec"},
+	{"spec", "template", "spec"},
+	{"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// ImageOverride overrides the images of the containers whose image names match, like the images of kustomize.
+type ImageOverride struct {
+	// Name is the name of the image to override, without tag and digest (eg: nginx or registry.io/app).
+	Name string
+	// NewName replaces the name of the image if it is not empty.
+	NewName string
+	// NewTag replaces the tag of the image if it is not empty.
+	NewTag string
+	// Digest replaces the tag and digest of the image if it is not empty.
+	Digest string
+}
+
+// SetNamespace sets the namespace of the namespaced objects, the objects of ClusterScopedKinds and the custom
+// resources whose CRDs in the list are cluster-scoped are left untouched.
+func SetNamespace(objs []*unstructured.Unstructured, namespace string) {
+	clusterScoped := ClusterScopedKinds.Clone()
+	for _, obj := range objs {
+		if obj.GetKind() != CustomResourceDefinitionKind {
+			continue
+		}
+		if scope, _, _ := unstructured.NestedString(obj.Object, "spec", "scope"); scope == "Cluster" {
+			group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+			kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+			clusterScoped.Insert(schema.GroupKind{Group: group, Kind: kind})
+		}
+	}
+	for _, obj := range objs {
+		if !clusterScoped.Has(obj.GroupVersionKind().GroupKind()) {
+			obj.SetNamespace(namespace)
+		}
+	}
+}
+
+// AddCommonLabels adds the labels to the objects and the pod templates of the workload objects, the selectors are
+// not changed since they are immutable for most of the workloads.
+func AddCommonLabels(objs []*unstructured.Unstructured, labels map[string]string) {
+	for _, obj := range objs {
+		SetLabels(obj, labels, MergeMode)
+		for _, path := range podTemplatePaths {
+			addNestedStringMap(obj.Object, labels, append(path, "metadata", "labels")...)
+		}
+	}
+}
+
+// AddCommonAnnotations adds the annotations to the objects and the pod templates of the workload objects.
+func AddCommonAnnotations(objs []*unstructured.Unstructured, annotations map[string]string) {
+	for _, obj := range objs {
+		SetAnnotations(obj, annotations, MergeMode)
+		for _, path := range podTemplatePaths {
+			addNestedStringMap(obj.Object, annotations, append(path, "metadata", "annotations")...)
+		}
+	}
+}
+
+// AddNamePrefixSuffix adds the prefix and suffix to the names of the objects. The Namespaces and CRDs are not
+// renamed since their names are meaningful, and the references to the renamed objects are not updated.
+func AddNamePrefixSuffix(objs []*unstructured.Unstructured, prefix, suffix string) {
+	for _, obj := range objs {
+		switch obj.GroupVersionKind().GroupKind() {
+		case schema.GroupKind{Kind: "Namespace"},
+			schema.GroupKind{Group: "apiextensions.k8s.io", Kind: CustomResourceDefinitionKind}:
+			continue
+		}
+		obj.SetName(prefix + obj.GetName() + suffix)
+	}
+}
+
+// SetImages overrides the images of the containers, init containers and ephemeral containers of the pods and the pod
+// templates of the workload objects, returns the number of updated containers.
+func SetImages(objs []*unstructured.Unstructured, overrides ...ImageOverride) int {
+	count := 0
+	for _, obj := range objs {
+		for _, path := range podSpecPaths {
+			for _, field := range []string{"containers", "initContainers", "ephemeralContainers"} {
+				containers, found, err := unstructured.NestedSlice(obj.Object, append(path, field)...)
+				if err != nil || !found {
+					continue
+				}
+				updated := false
+				for _, c := range containers {
+					container, ok := c.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					image, ok := container["image"].(string)
+					if !ok {
+						continue
+					}
+					for _, override := range overrides {
+						newImage, ok := overrideImage(image, override)
+						if !ok {
+							continue
+						}
+						if newImage != image {
+							container["image"] = newImage
+							updated = true
+							count++
+						}
+						break
+					}
+				}
+				if updated {
+					_ = unstructured.SetNestedSlice(obj.Object, containers, append(path, field)...)
+				}
+			}
+		}
+	}
+	return count
+}
+
+// overrideImage returns the overridden image if the image name matches the override.
+func overrideImage(image string, override ImageOverride) (string, bool) {
+	name, tag, digest := splitImage(image)
+	if name != override.Name {
+		return "", false
+	}
+	if override.NewName != "" {
+		name = override.NewName
+	}
+	switch {
+	case override.Digest != "":
+		return name + "@" + override.Digest, true
+	case override.NewTag != "":
+		return name + ":" + override.NewTag, true
+	case digest != "":
+		return name + "@" + digest, true
+	case tag != "":
+		return name + ":" + tag, true
+	default:
+		return name, true
+	}
+}
+
+// splitImage splits the image (eg: registry.io:5000/app:v1@sha256:...) into its name, tag and digest.
+func splitImage(image string) (string, string, string) {
+	name, digest, _ := strings.Cut(image, "@")
+	tag := ""
+	// the colon of a registry port is followed by a slash
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	return name, tag, digest
+}
+
+// addNestedStringMap merges the values into the nested string map if its parent exists.
+func addNestedStringMap(obj map[string]interface{}, values map[string]string, fields ...string) {
+	if _, found, err := unstructured.NestedMap(obj, fields[:len(fields)-2]...); err != nil || !found {
+		return
+	}
+	result, _, err := unstructured.NestedStringMap(obj, fields...)
+	if err != nil {
+		return
+	}
+	if result == nil {
+		result = make(map[string]string, len(values))
+	}
+	for k, v := range values {
+		result[k] = v
+	}
+	_ = unstructured.SetNestedStringMap(obj, result, fields...)
+}
@@ -0,0 +1,171 @@
+package object
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const transformManifests = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: web
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: clusterfoos.example.com
+spec:
+  group: example.com
+  scope: Cluster
+  names:
+    kind: ClusterFoo
+---
+apiVersion: example.com/v1
+kind: ClusterFoo
+metadata:
+  name: foo
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+spec:
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      initContainers:
+      - name: init
+        image: registry.io:5000/init@sha256:aaa
+      containers:
+      - name: web
+        image: web:v1
+      - name: sidecar
+        image: sidecar
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: job
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: job
+            image: web:v1
+`
+
+func newTransformObjects(t *testing.T) []*unstructured.Unstructured {
+	objs, err := LoadManifests(strings.NewReader(transformManifests))
+	if err != nil {
+		t.Fatalf("LoadManifests() unexpected error: %v", err)
+	}
+	return objs
+}
+
+func TestSetNamespace(t *testing.T) {
+	objs := newTransformObjects(t)
+	SetNamespace(objs, "prod")
+	wanted := []string{"", "", "", "prod", "prod"}
+	for i, obj := range objs {
+		if obj.GetNamespace() != wanted[i] {
+			t.Errorf("SetNamespace() %s namespace = %q, want %q", obj.GetKind(), obj.GetNamespace(), wanted[i])
+		}
+	}
+}
+
+func TestAddCommonLabels(t *testing.T) {
+	objs := newTransformObjects(t)
+	AddCommonLabels(objs, map[string]string{"team": "a"})
+	AddCommonAnnotations(objs, map[string]string{"owner": "b"})
+	for _, obj := range objs {
+		if obj.GetLabels()["team"] != "a" || obj.GetAnnotations()["owner"] != "b" {
+			t.Errorf("AddCommonLabels() %s labels = %v, annotations = %v", obj.GetKind(), obj.GetLabels(),
+				obj.GetAnnotations())
+		}
+	}
+
+	labels, _, _ := unstructured.NestedStringMap(objs[3].Object, "spec", "template", "metadata", "labels")
+	if wanted := map[string]string{"app": "web", "team": "a"}; !reflect.DeepEqual(labels, wanted) {
+		t.Errorf("AddCommonLabels() template labels = %v, want %v", labels, wanted)
+	}
+	annotations, _, _ := unstructured.NestedStringMap(objs[4].Object,
+		"spec", "jobTemplate", "spec", "template", "metadata", "annotations")
+	if wanted := map[string]string{"owner": "b"}; !reflect.DeepEqual(annotations, wanted) {
+		t.Errorf("AddCommonAnnotations() job template annotations = %v, want %v", annotations, wanted)
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(objs[0].Object, "spec"); found {
+		t.Errorf("AddCommonLabels() added a pod template to %s", objs[0].GetKind())
+	}
+}
+
+func TestAddNamePrefixSuffix(t *testing.T) {
+	objs := newTransformObjects(t)
+	AddNamePrefixSuffix(objs, "dev-", "-v1")
+	wanted := []string{"web", "clusterfoos.example.com", "dev-foo-v1", "dev-web-v1", "dev-job-v1"}
+	for i, obj := range objs {
+		if obj.GetName() != wanted[i] {
+			t.Errorf("AddNamePrefixSuffix() name = %s, want %s", obj.GetName(), wanted[i])
+		}
+	}
+}
+
+func TestSetImages(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides []ImageOverride
+		count     int
+		wanted    []string
+	}{
+		{
+			name:      "new tag test",
+			overrides: []ImageOverride{{Name: "web", NewTag: "v2"}},
+			count:     2,
+			wanted:    []string{"registry.io:5000/init@sha256:aaa", "web:v2", "sidecar", "web:v2"},
+		},
+		{
+			name: "new name and digest test",
+			overrides: []ImageOverride{
+				{Name: "registry.io:5000/init", NewName: "mirror.io/init"},
+				{Name: "sidecar", Digest: "sha256:bbb"},
+			},
+			count:  2,
+			wanted: []string{"mirror.io/init@sha256:aaa", "web:v1", "sidecar@sha256:bbb", "web:v1"},
+		},
+		{
+			name:      "no match test",
+			overrides: []ImageOverride{{Name: "web:v1", NewTag: "v2"}},
+			count:     0,
+			wanted:    []string{"registry.io:5000/init@sha256:aaa", "web:v1", "sidecar", "web:v1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := newTransformObjects(t)
+			if count := SetImages(objs, tt.overrides...); count != tt.count {
+				t.Errorf("SetImages() = %d, want %d", count, tt.count)
+			}
+			got := make([]string, 0)
+			for _, obj := range objs {
+				for _, path := range podSpecPaths {
+					for _, field := range []string{"initContainers", "containers"} {
+						containers, _, _ := unstructured.NestedSlice(obj.Object, append(path, field)...)
+						for _, c := range containers {
+							got = append(got, c.(map[string]interface{})["image"].(string))
+						}
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("SetImages() images = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package object
+
+import (
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var metadataPath = field.NewPath("metadata")
+
+// AddValidatedAnnotation adds an annotation to the object like AddAnnotation, but the key must be a qualified name
+// and the total size of the object's annotations must not exceed 256KiB. The object is left untouched if the
+// annotation is invalid, the field errors are returned as an aggregated error.
+func AddValidatedAnnotation(obj metav1.Object, k, v string) (bool, error) {
+	annotations := make(map[string]string, len(obj.GetAnnotations())+1)
+	for key, value := range obj.GetAnnotations() {
+		annotations[key] = value
+	}
+	annotations[k] = v
+	if errs := apivalidation.ValidateAnnotations(annotations, metadataPath.Child("annotations")); len(errs) > 0 {
+		return false, errs.ToAggregate()
+	}
+	return AddAnnotation(obj, k, v), nil
+}
+
+// AddValidatedLabel adds a label to the object like AddLabel, but the key must be a qualified name and the value
+// must be a valid label value (at most 63 characters, alphanumeric with '-', '_' or '.' inside). The object is left
+// untouched if the label is invalid, the field errors are returned as an aggregated error.
+func AddValidatedLabel(obj metav1.Object, k, v string) (bool, error) {
+	if errs := metav1validation.ValidateLabels(map[string]string{k: v}, metadataPath.Child("labels")); len(errs) > 0 {
+		return false, errs.ToAggregate()
+	}
+	return AddLabel(obj, k, v), nil
+}
+
+// ValidateMetadata validates the labels, annotations, finalizers and owner references of the object.
+func ValidateMetadata(obj metav1.Object) field.ErrorList {
+	errs := field.ErrorList{}
+	errs = append(errs, metav1validation.ValidateLabels(obj.GetLabels(), metadataPath.Child("labels"))...)
+	errs = append(errs, apivalidation.ValidateAnnotations(obj.GetAnnotations(), metadataPath.Child("annotations"))...)
+	errs = append(errs, apivalidation.ValidateFinalizers(obj.GetFinalizers(), metadataPath.Child("finalizers"))...)
+	errs = append(errs, apivalidation.ValidateOwnerReferences(
+		obj.GetOwnerReferences(), metadataPath.Child("ownerReferences"))...)
+	return errs
+}
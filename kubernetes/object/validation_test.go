@@ -0,0 +1,157 @@
+package object
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAddValidatedAnnotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     metav1.Object
+		k, v    string
+		isErr   bool
+		updated bool
+		wanted  map[string]string
+	}{
+		{
+			name:    "normal test",
+			obj:     &corev1.Node{},
+			k:       "example.com/aa",
+			v:       "bb",
+			updated: true,
+			wanted: map[string]string{
+				"example.com/aa": "bb",
+			},
+		},
+		{
+			name:   "invalid key test",
+			obj:    &corev1.Node{},
+			k:      "aa bb",
+			v:      "cc",
+			isErr:  true,
+			wanted: nil,
+		},
+		{
+			name: "too large test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"aa": strings.Repeat("x", 200*1024),
+					},
+				},
+			},
+			k:     "bb",
+			v:     strings.Repeat("x", 100*1024),
+			isErr: true,
+			wanted: map[string]string{
+				"aa": strings.Repeat("x", 200*1024),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddValidatedAnnotation(tt.obj, tt.k, tt.v)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s AddValidatedAnnotation() unexpected error: %v", tt.name, err)
+			}
+			if got != tt.updated {
+				t.Errorf("AddValidatedAnnotation() = %v, want %v", got, tt.updated)
+			}
+			if !reflect.DeepEqual(tt.obj.GetAnnotations(), tt.wanted) {
+				t.Errorf("AddValidatedAnnotation() = %v, want %v", len(tt.obj.GetAnnotations()), len(tt.wanted))
+			}
+		})
+	}
+}
+
+func TestAddValidatedLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		k, v    string
+		isErr   bool
+		updated bool
+	}{
+		{
+			name:    "normal test",
+			k:       "app.kubernetes.io/name",
+			v:       "web",
+			updated: true,
+		},
+		{
+			name:  "invalid key test",
+			k:     "-app",
+			v:     "web",
+			isErr: true,
+		},
+		{
+			name:  "invalid value test",
+			k:     "app",
+			v:     "web app",
+			isErr: true,
+		},
+		{
+			name:  "too long value test",
+			k:     "app",
+			v:     strings.Repeat("a", 64),
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &corev1.Node{}
+			got, err := AddValidatedLabel(obj, tt.k, tt.v)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s AddValidatedLabel() unexpected error: %v", tt.name, err)
+			}
+			if got != tt.updated {
+				t.Errorf("AddValidatedLabel() = %v, want %v", got, tt.updated)
+			}
+			if tt.isErr && obj.GetLabels() != nil {
+				t.Errorf("AddValidatedLabel() = %v, want nil", obj.GetLabels())
+			}
+		})
+	}
+}
+
+func TestValidateMetadata(t *testing.T) {
+	tests := []struct {
+		name   string
+		obj    metav1.Object
+		wanted int
+	}{
+		{
+			name: "valid test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "web"},
+					Annotations: map[string]string{"example.com/aa": "bb cc"},
+					Finalizers:  []string{"example.com/finalizer"},
+				},
+			},
+			wanted: 0,
+		},
+		{
+			name: "invalid test",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "web app"},
+					Annotations: map[string]string{"aa/bb/cc": "dd"},
+					Finalizers:  []string{"-finalizer"},
+				},
+			},
+			wanted: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateMetadata(tt.obj); len(got) != tt.wanted {
+				t.Errorf("ValidateMetadata() = %v, want %v errors", got, tt.wanted)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package object
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+// OutputFormat is the format of the manifests written by WriteManifests.
+type OutputFormat string
+
+const (
+	// YAMLOutput writes the objects as YAML documents separated by --- lines.
+	YAMLOutput OutputFormat = "yaml"
+	// JSONOutput writes the objects as the items of a v1 List.
+	JSONOutput OutputFormat = "json"
+)
+
+// WriteOptions defines options needed to write manifests.
+type WriteOptions struct {
+	format      OutputFormat
+	stripFields []string
+	scheme      *runtime.Scheme
+}
+
+// WithOutputFormat sets the format of the manifests.
+// The default value is YAMLOutput.
+func WithOutputFormat(format OutputFormat) func(*WriteOptions) {
+	return func(o *WriteOptions) {
+		o.format = format
+	}
+}
+
+// WithStripFields adds the fields (eg: metadata.managedFields) to remove from the written objects, the objects
+// themselves are not modified.
+func WithStripFields(paths ...string) func(*WriteOptions) {
+	return func(o *WriteOptions) {
+		o.stripFields = append(o.stripFields, paths...)
+	}
+}
+
+// WithWriteScheme sets the scheme used to look up the apiVersion and kind of typed objects.
+// The default value is Kubernetes scheme.
+func WithWriteScheme(scheme *runtime.Scheme) func(*WriteOptions) {
+	return func(o *WriteOptions) {
+		o.scheme = scheme
+	}
+}
+
+// WriteManifests serialises the typed or unstructured objects into a multi-document YAML stream (or a JSON v1 List).
+// The object keys are sorted, so the output is deterministic for the same objects and suitable for golden files.
+func WriteManifests(w io.Writer, objs []runtime.Object, options ...func(*WriteOptions)) error {
+	opts := &WriteOptions{
+		format: YAMLOutput,
+		scheme: clientgoscheme.Scheme,
+	}
+	for _, f := range options {
+		f(opts)
+	}
+
+	items := make([]interface{}, 0, len(objs))
+	for i, obj := range objs {
+		content, err := writableContent(obj, opts)
+		if err != nil {
+			return fmt.Errorf("object %d: %v", i, err)
+		}
+		items = append(items, content)
+	}
+
+	switch opts.format {
+	case YAMLOutput:
+		for i, item := range items {
+			data, err := yaml.Marshal(item)
+			if err != nil {
+				return fmt.Errorf("object %d: %v", i, err)
+			}
+			if i > 0 {
+				if _, err = io.WriteString(w, "---\n"); err != nil {
+					return err
+				}
+			}
+			if _, err = w.Write(data); err != nil {
+				return err
+			}
+		}
+		return nil
+	case JSONOutput:
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      items,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	default:
+		return fmt.Errorf("unsupported output format %q", opts.format)
+	}
+}
+
+// MarshalManifests is the same as WriteManifests but returns the serialised manifests.
+func MarshalManifests(objs []runtime.Object, options ...func(*WriteOptions)) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteManifests(&buf, objs, options...); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writableContent returns a copy of the object content with the apiVersion and kind filled in and the fields removed.
+func writableContent(obj runtime.Object, opts *WriteOptions) (map[string]interface{}, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if ok {
+		u = u.DeepCopy()
+	} else {
+		gvk, err := apiutil.GVKForObject(obj, opts.scheme)
+		if err != nil {
+			return nil, err
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		u = &unstructured.Unstructured{Object: content}
+		u.SetGroupVersionKind(gvk)
+	}
+	for _, path := range opts.stripFields {
+		unstructured.RemoveNestedField(u.Object, strings.Split(path, ".")...)
+	}
+	return u.Object, nil
+}
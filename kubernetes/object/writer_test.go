@@ -0,0 +1,102 @@
+package object
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestMarshalManifests(t *testing.T) {
+	newObjs := func() []runtime.Object {
+		return []runtime.Object{
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "default", UID: "uid"},
+				Data:       map[string]string{"b": "2", "a": "1"},
+			},
+			&unstructured.Unstructured{Object: map[string]interface{}{
+				"kind":       "Namespace",
+				"apiVersion": "v1",
+				"metadata":   map[string]interface{}{"name": "ns1", "uid": "uid"},
+			}},
+		}
+	}
+	tests := []struct {
+		name    string
+		objs    []runtime.Object
+		options []func(*WriteOptions)
+		isErr   bool
+		wanted  string
+	}{
+		{
+			name:    "yaml test",
+			objs:    newObjs(),
+			options: []func(*WriteOptions){WithStripFields("metadata.uid", "metadata.creationTimestamp")},
+			wanted: `apiVersion: v1
+data:
+  a: "1"
+  b: "2"
+kind: ConfigMap
+metadata:
+  name: cm1
+  namespace: default
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns1
+`,
+		},
+		{
+			name: "json test",
+			objs: newObjs()[1:],
+			options: []func(*WriteOptions){
+				WithOutputFormat(JSONOutput),
+				WithStripFields("metadata.uid"),
+			},
+			wanted: `{
+  "apiVersion": "v1",
+  "items": [
+    {
+      "apiVersion": "v1",
+      "kind": "Namespace",
+      "metadata": {
+        "name": "ns1"
+      }
+    }
+  ],
+  "kind": "List"
+}
+`,
+		},
+		{
+			name:   "empty test",
+			objs:   nil,
+			wanted: "",
+		},
+		{
+			name:    "unsupported format test",
+			objs:    newObjs(),
+			options: []func(*WriteOptions){WithOutputFormat("xml")},
+			isErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := MarshalManifests(tt.objs, tt.options...)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s MarshalManifests() unexpected error: %v", tt.name, err)
+			}
+			if string(data) != tt.wanted {
+				t.Errorf("MarshalManifests() = %s, want %s", data, tt.wanted)
+			}
+			for _, obj := range tt.objs {
+				if u, ok := obj.(*unstructured.Unstructured); ok && u.GetUID() == "" {
+					t.Errorf("MarshalManifests() modified the object %s", u.GetName())
+				}
+			}
+		})
+	}
+}
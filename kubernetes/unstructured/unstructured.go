@@ -1,11 +1,28 @@
 package unstructured
 
 import (
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/yaml"
 )
 
+// ConvertOptions defines options needed to convert between typed and unstructured objects.
+type ConvertOptions struct {
+	scheme *runtime.Scheme
+}
+
+// WithScheme sets the scheme used to look up the apiVersion and kind of typed objects.
+// The default value is Kubernetes scheme.
+func WithScheme(scheme *runtime.Scheme) func(*ConvertOptions) {
+	return func(o *ConvertOptions) {
+		o.scheme = scheme
+	}
+}
+
 // ConvertToUnstructured converts a typed object to an unstructured object.
 func ConvertToUnstructured(obj interface{}) (*unstructured.Unstructured, error) {
 	unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
@@ -36,3 +53,117 @@ func YAMLToUnstructured(content []byte) (*unstructured.Unstructured, error) {
 		Object: obj,
 	}, nil
 }
+
+// ToTyped converts an unstructured object to a new typed object of type T, the apiVersion and kind of the typed
+// object are filled in from the scheme. An error is returned if the apiVersion or kind of the object is set and does
+// not match the type T.
+// Usage:
+//
+//	pod, err := ToTyped[corev1.Pod](object)
+func ToTyped[T any, PT interface {
+	*T
+	runtime.Object
+}](obj runtime.Unstructured, options ...func(*ConvertOptions)) (PT, error) {
+	opts := newConvertOptions(options...)
+	return toTyped[T, PT](obj.UnstructuredContent(), opts)
+}
+
+// FromTyped converts a typed object to an unstructured object, the apiVersion and kind are filled in from the scheme
+// since they are usually empty in typed objects.
+func FromTyped(obj runtime.Object, options ...func(*ConvertOptions)) (*unstructured.Unstructured, error) {
+	opts := newConvertOptions(options...)
+	return fromTyped(obj, opts)
+}
+
+// ToTypedSlice converts the items of an unstructured list to typed objects of type T, the apiVersion and kind of the
+// items are checked the same way as ToTyped.
+// Usage:
+//
+//	pods, err := ToTypedSlice[corev1.Pod](list)
+func ToTypedSlice[T any, PT interface {
+	*T
+	runtime.Object
+}](list *unstructured.UnstructuredList, options ...func(*ConvertOptions)) ([]T, error) {
+	opts := newConvertOptions(options...)
+	result := make([]T, 0, len(list.Items))
+	for i := range list.Items {
+		obj, err := toTyped[T, PT](list.Items[i].Object, opts)
+		if err != nil {
+			return nil, fmt.Errorf("items[%d]: %v", i, err)
+		}
+		result = append(result, *obj)
+	}
+	return result, nil
+}
+
+// FromTypedSlice converts the typed objects to an unstructured list, the apiVersion and kind of the items are filled
+// in from the scheme and the kind of the list is the kind of the items followed by List (eg: PodList).
+func FromTypedSlice[T any, PT interface {
+	*T
+	runtime.Object
+}](items []T, options ...func(*ConvertOptions)) (*unstructured.UnstructuredList, error) {
+	opts := newConvertOptions(options...)
+	list := &unstructured.UnstructuredList{
+		Object: make(map[string]interface{}),
+		Items:  make([]unstructured.Unstructured, 0, len(items)),
+	}
+	gvk, err := apiutil.GVKForObject(PT(new(T)), opts.scheme)
+	if err != nil {
+		return nil, err
+	}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	for i := range items {
+		obj, err := fromTyped(PT(&items[i]), opts)
+		if err != nil {
+			return nil, fmt.Errorf("items[%d]: %v", i, err)
+		}
+		list.Items = append(list.Items, *obj)
+	}
+	return list, nil
+}
+
+func newConvertOptions(options ...func(*ConvertOptions)) *ConvertOptions {
+	opts := &ConvertOptions{
+		scheme: clientgoscheme.Scheme,
+	}
+	for _, f := range options {
+		f(opts)
+	}
+	return opts
+}
+
+func toTyped[T any, PT interface {
+	*T
+	runtime.Object
+}](content map[string]interface{}, opts *ConvertOptions) (PT, error) {
+	obj := PT(new(T))
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, obj); err != nil {
+		return nil, err
+	}
+	gvk, err := apiutil.GVKForObject(obj, opts.scheme)
+	if err != nil {
+		return nil, err
+	}
+	// the apiVersion and kind of the content are decoded into the type meta of the object
+	objGVK := obj.GetObjectKind().GroupVersionKind()
+	kindMismatch := objGVK.Kind != "" && objGVK.Kind != gvk.Kind
+	versionMismatch := objGVK.Version != "" && objGVK.GroupVersion() != gvk.GroupVersion()
+	if kindMismatch || versionMismatch {
+		return nil, fmt.Errorf("cannot convert %s to %s", objGVK, gvk)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	return obj, nil
+}
+
+func fromTyped(obj runtime.Object, opts *ConvertOptions) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(obj, opts.scheme)
+	if err != nil {
+		return nil, err
+	}
+	u, err := ConvertToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
@@ -137,3 +137,167 @@ metadata:
 		})
 	}
 }
+
+func TestToTyped(t *testing.T) {
+	tests := []struct {
+		name   string
+		obj    runtime.Unstructured
+		isErr  bool
+		wanted string
+	}{
+		{
+			name: "normal test",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name": "test",
+					},
+				},
+			},
+			isErr:  false,
+			wanted: "/v1, Kind=Namespace",
+		},
+		{
+			name: "matched kind test",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Namespace",
+					"metadata": map[string]interface{}{
+						"name": "test",
+					},
+				},
+			},
+			isErr:  false,
+			wanted: "/v1, Kind=Namespace",
+		},
+		{
+			name: "error test",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": "test",
+				},
+			},
+			isErr: true,
+		},
+		{
+			name: "mismatched kind test",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata": map[string]interface{}{
+						"name": "test",
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			name: "mismatched apiVersion test",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v2",
+					"kind":       "Namespace",
+					"metadata": map[string]interface{}{
+						"name": "test",
+					},
+				},
+			},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns, err := ToTyped[corev1.Namespace](tt.obj)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s ToTyped() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			if got := ns.GroupVersionKind().String(); got != tt.wanted || ns.Name != "test" {
+				t.Errorf("ToTyped() = %s %s, want %s test", got, ns.Name, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestFromTyped(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     runtime.Object
+		options []func(*ConvertOptions)
+		isErr   bool
+		wanted  string
+	}{
+		{
+			name: "normal test",
+			obj: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+			},
+			isErr:  false,
+			wanted: "/v1, Kind=Namespace",
+		},
+		{
+			name: "unregistered test",
+			obj: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+			},
+			options: []func(*ConvertOptions){WithScheme(runtime.NewScheme())},
+			isErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := FromTyped(tt.obj, tt.options...)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s FromTyped() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			if got := obj.GroupVersionKind().String(); got != tt.wanted {
+				t.Errorf("FromTyped() = %s, want %s", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestTypedSlice(t *testing.T) {
+	namespaces := []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+	}
+	list, err := FromTypedSlice(namespaces)
+	if err != nil {
+		t.Fatalf("FromTypedSlice() unexpected error: %v", err)
+	}
+	if got := list.GroupVersionKind().String(); got != "/v1, Kind=NamespaceList" {
+		t.Errorf("FromTypedSlice() list = %s, want /v1, Kind=NamespaceList", got)
+	}
+	if len(list.Items) != 2 || list.Items[1].GetKind() != "Namespace" || list.Items[1].GetName() != "b" {
+		t.Errorf("FromTypedSlice() items = %v", list.Items)
+	}
+
+	result, err := ToTypedSlice[corev1.Namespace](list)
+	if err != nil {
+		t.Fatalf("ToTypedSlice() unexpected error: %v", err)
+	}
+	if len(result) != 2 || result[0].Name != "a" || result[0].Kind != "Namespace" {
+		t.Errorf("ToTypedSlice() = %v", result)
+	}
+
+	if _, err = ToTypedSlice[corev1.Pod](list); err == nil {
+		t.Errorf("ToTypedSlice() expected an error for items of a different kind")
+	}
+
+	list.Items[0].Object["metadata"] = "a"
+	if _, err = ToTypedSlice[corev1.Namespace](list); err == nil {
+		t.Errorf("ToTypedSlice() expected an error for an invalid item")
+	}
+}
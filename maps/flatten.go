@@ -0,0 +1,145 @@
+package maps
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultSeparator is the separator used by Flatten and Unflatten when an empty separator is given.
+const DefaultSeparator = "."
+
+// Flatten flattens the nested source map into a single level map keyed by the paths of the leaf values joined by
+// the separator, array elements are keyed by their indices. Empty maps and arrays are kept as leaf values.
+// An error is returned if two paths are flattened into the same key, which happens when keys contain the separator
+// themselves (eg: a.b and a: {b}).
+//
+// eg:
+//
+//	src := map[string]interface{}{
+//		"a": map[string]interface{}{
+//			"b": 1,
+//			"c": []interface{}{"x", "y"},
+//		},
+//	}
+//	Flatten(src, ".")
+//
+// result: map[a.b:1 a.c.0:x a.c.1:y]
+func Flatten(src map[string]interface{}, sep string) (map[string]interface{}, error) {
+	if sep == "" {
+		sep = DefaultSeparator
+	}
+	result := make(map[string]interface{})
+	for _, k := range sortedKeys(src) {
+		if err := flatten(result, k, src[k], sep); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func flatten(result map[string]interface{}, prefix string, value interface{}, sep string) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return setFlattenedKey(result, prefix, v)
+		}
+		for _, k := range sortedKeys(v) {
+			if err := flatten(result, prefix+sep+k, v[k], sep); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return setFlattenedKey(result, prefix, v)
+		}
+		for i, item := range v {
+			if err := flatten(result, prefix+sep+strconv.Itoa(i), item, sep); err != nil {
+				return err
+			}
+		}
+	default:
+		return setFlattenedKey(result, prefix, value)
+	}
+	return nil
+}
+
+func setFlattenedKey(result map[string]interface{}, key string, value interface{}) error {
+	if _, ok := result[key]; ok {
+		return fmt.Errorf("multiple paths are flattened into key %q", key)
+	}
+	result[key] = value
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Unflatten is the inverse of Flatten, it rebuilds the nested map from the flattened keys split by the separator.
+// Nested maps whose keys are exactly the indices 0..n-1 are turned back into arrays.
+// An error is returned if a key is both a leaf value and the parent of another key (eg: a and a.b).
+//
+// eg:
+//
+//	src := map[string]interface{}{
+//		"a.b":   1,
+//		"a.c.0": "x",
+//	}
+//	Unflatten(src, ".")
+//
+// result: map[a:map[b:1 c:[x]]]
+func Unflatten(src map[string]interface{}, sep string) (map[string]interface{}, error) {
+	if sep == "" {
+		sep = DefaultSeparator
+	}
+	keys := sortedKeys(src)
+	for _, k := range keys {
+		parts := strings.Split(k, sep)
+		for i := 1; i < len(parts); i++ {
+			prefix := strings.Join(parts[:i], sep)
+			if _, ok := src[prefix]; ok {
+				return nil, fmt.Errorf("key %q collides with key %q", k, prefix)
+			}
+		}
+	}
+
+	result := make(map[string]interface{})
+	for _, k := range keys {
+		setNestedField(result, strings.Split(k, sep), src[k])
+	}
+	for k, v := range result {
+		result[k] = indexMapsToSlices(v)
+	}
+	return result, nil
+}
+
+// indexMapsToSlices converts the nested maps whose keys are exactly the indices 0..n-1 into slices.
+func indexMapsToSlices(value interface{}) interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	for k, v := range m {
+		m[k] = indexMapsToSlices(v)
+	}
+
+	if len(m) == 0 {
+		return m
+	}
+	items := make([]interface{}, len(m))
+	for k, v := range m {
+		index, err := strconv.Atoi(k)
+		if err != nil || index < 0 || index >= len(m) || strconv.Itoa(index) != k {
+			return m
+		}
+		items[index] = v
+	}
+	return items
+}
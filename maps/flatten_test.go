@@ -0,0 +1,165 @@
+package maps
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    map[string]interface{}
+		sep    string
+		isErr  bool
+		wanted map[string]interface{}
+	}{
+		{
+			name: "normal test",
+			src: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": 1,
+					"c": []interface{}{
+						"x",
+						map[string]interface{}{
+							"d": true,
+						},
+					},
+				},
+				"e": "f",
+			},
+			sep: ".",
+			wanted: map[string]interface{}{
+				"a.b":     1,
+				"a.c.0":   "x",
+				"a.c.1.d": true,
+				"e":       "f",
+			},
+		},
+		{
+			name: "separator test",
+			src: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": 1,
+				},
+			},
+			sep: "_",
+			wanted: map[string]interface{}{
+				"a_b": 1,
+			},
+		},
+		{
+			name: "empty test",
+			src: map[string]interface{}{
+				"a": map[string]interface{}{},
+				"b": []interface{}{},
+			},
+			wanted: map[string]interface{}{
+				"a": map[string]interface{}{},
+				"b": []interface{}{},
+			},
+		},
+		{
+			name: "collision test",
+			src: map[string]interface{}{
+				"a.b": 1,
+				"a": map[string]interface{}{
+					"b": 2,
+				},
+			},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Flatten(tt.src, tt.sep)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s Flatten() unexpected error: %v", tt.name, err)
+			}
+			if !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("Flatten() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestUnflatten(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    map[string]interface{}
+		sep    string
+		isErr  bool
+		wanted map[string]interface{}
+	}{
+		{
+			name: "normal test",
+			src: map[string]interface{}{
+				"a.b":     1,
+				"a.c.0":   "x",
+				"a.c.1.d": true,
+				"e":       "f",
+			},
+			sep: ".",
+			wanted: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": 1,
+					"c": []interface{}{
+						"x",
+						map[string]interface{}{
+							"d": true,
+						},
+					},
+				},
+				"e": "f",
+			},
+		},
+		{
+			name: "separator test",
+			src: map[string]interface{}{
+				"a__b": 1,
+			},
+			sep: "__",
+			wanted: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": 1,
+				},
+			},
+		},
+		{
+			name: "not index test",
+			src: map[string]interface{}{
+				"a.0": 1,
+				"a.2": 2,
+				"0":   3,
+			},
+			wanted: map[string]interface{}{
+				"a": map[string]interface{}{
+					"0": 1,
+					"2": 2,
+				},
+				"0": 3,
+			},
+		},
+		{
+			name: "collision test",
+			src: map[string]interface{}{
+				"a":   1,
+				"a.b": 2,
+			},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Unflatten(tt.src, tt.sep)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s Unflatten() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("Unflatten() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package maps
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Keys returns the sorted keys of the map.
+func Keys[M ~map[K]V, K constraints.Ordered, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// Values returns the values of the map in the order of their sorted keys.
+func Values[M ~map[K]V, K constraints.Ordered, V any](m M) []V {
+	values := make([]V, 0, len(m))
+	for _, k := range Keys(m) {
+		values = append(values, m[k])
+	}
+	return values
+}
+
+// Filter returns a new map that only contains the entries for which fn returns true.
+func Filter[M ~map[K]V, K comparable, V any](m M, fn func(K, V) bool) M {
+	result := make(M)
+	for k, v := range m {
+		if fn(k, v) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// MapValues returns a new map with the same keys and the values transformed by fn.
+func MapValues[M ~map[K]V, K comparable, V, R any](m M, fn func(V) R) map[K]R {
+	result := make(map[K]R, len(m))
+	for k, v := range m {
+		result[k] = fn(v)
+	}
+	return result
+}
+
+// Invert returns a new map with the keys and values swapped, an error is returned if several keys have the same
+// value.
+func Invert[M ~map[K]V, K, V comparable](m M) (map[V]K, error) {
+	result := make(map[V]K, len(m))
+	for k, v := range m {
+		if existing, ok := result[v]; ok {
+			return nil, fmt.Errorf("keys %v and %v have the same value %v", existing, k, v)
+		}
+		result[v] = k
+	}
+	return result, nil
+}
+
+// GroupBy splits the map into groups keyed by the result of fn.
+//
+// eg:
+//
+//	labels := map[string]string{"app": "web", "team": "infra", "tier": "web"}
+//	GroupBy(labels, func(k, v string) string { return v })
+//
+// result: map[infra:map[team:infra] web:map[app:web tier:web]]
+func GroupBy[M ~map[K]V, K comparable, V any, G comparable](m M, fn func(K, V) G) map[G]M {
+	result := make(map[G]M)
+	for k, v := range m {
+		g := fn(k, v)
+		if result[g] == nil {
+			result[g] = make(M)
+		}
+		result[g][k] = v
+	}
+	return result
+}
+
+// Union returns a new map that contains the entries of all the maps, later maps take precedence over earlier ones
+// for the same key.
+func Union[M ~map[K]V, K comparable, V any](ms ...M) M {
+	result := make(M)
+	for _, m := range ms {
+		for k, v := range m {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Intersect returns a new map that contains the entries (same key and value) present in both maps.
+func Intersect[M ~map[K]V, K, V comparable](a, b M) M {
+	result := make(M)
+	for k, v := range a {
+		if bv, ok := b[k]; ok && bv == v {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Difference returns a new map that contains the entries of a that are not present (same key and value) in b.
+func Difference[M ~map[K]V, K, V comparable](a, b M) M {
+	result := make(M)
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// EqualFunc determines whether two maps contain the same keys and their values are equal according to eq.
+func EqualFunc[M1 ~map[K]V1, M2 ~map[K]V2, K comparable, V1, V2 any](a M1, b M2, eq func(V1, V2) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, av := range a {
+		bv, ok := b[k]
+		if !ok || !eq(av, bv) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+package maps
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeysAndValues(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "b": 2}
+	if got := Keys(m); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Keys() = %v, want %v", got, []string{"a", "b", "c"})
+	}
+	if got := Values(m); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Values() = %v, want %v", got, []int{1, 2, 3})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	labels := map[string]string{
+		"app":                    "web",
+		"app.kubernetes.io/name": "web",
+	}
+	got := Filter(labels, func(k, _ string) bool {
+		return strings.Contains(k, "/")
+	})
+	wanted := map[string]string{"app.kubernetes.io/name": "web"}
+	if !reflect.DeepEqual(got, wanted) {
+		t.Errorf("Filter() = %v, want %v", got, wanted)
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	got := MapValues(map[string]string{"a": "x", "b": "yy"}, func(v string) int {
+		return len(v)
+	})
+	wanted := map[string]int{"a": 1, "b": 2}
+	if !reflect.DeepEqual(got, wanted) {
+		t.Errorf("MapValues() = %v, want %v", got, wanted)
+	}
+}
+
+func TestInvert(t *testing.T) {
+	tests := []struct {
+		name   string
+		m      map[string]string
+		isErr  bool
+		wanted map[string]string
+	}{
+		{
+			name:   "normal test",
+			m:      map[string]string{"a": "x", "b": "y"},
+			wanted: map[string]string{"x": "a", "y": "b"},
+		},
+		{
+			name:  "duplicate test",
+			m:     map[string]string{"a": "x", "b": "x"},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Invert(tt.m)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s Invert() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("Invert() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	labels := map[string]string{"app": "web", "team": "infra", "tier": "web"}
+	got := GroupBy(labels, func(_, v string) string {
+		return v
+	})
+	wanted := map[string]map[string]string{
+		"web":   {"app": "web", "tier": "web"},
+		"infra": {"team": "infra"},
+	}
+	if !reflect.DeepEqual(got, wanted) {
+		t.Errorf("GroupBy() = %v, want %v", got, wanted)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := map[string]string{"a": "1", "b": "2", "c": "3"}
+	b := map[string]string{"b": "2", "c": "4", "d": "5"}
+
+	union := map[string]string{"a": "1", "b": "2", "c": "4", "d": "5"}
+	if got := Union(a, b); !reflect.DeepEqual(got, union) {
+		t.Errorf("Union() = %v, want %v", got, union)
+	}
+	intersect := map[string]string{"b": "2"}
+	if got := Intersect(a, b); !reflect.DeepEqual(got, intersect) {
+		t.Errorf("Intersect() = %v, want %v", got, intersect)
+	}
+	difference := map[string]string{"a": "1", "c": "3"}
+	if got := Difference(a, b); !reflect.DeepEqual(got, difference) {
+		t.Errorf("Difference() = %v, want %v", got, difference)
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	tests := []struct {
+		name   string
+		a      map[string]string
+		b      map[string]string
+		wanted bool
+	}{
+		{
+			name:   "equal test",
+			a:      map[string]string{"a": "X"},
+			b:      map[string]string{"a": "x"},
+			wanted: true,
+		},
+		{
+			name:   "different value test",
+			a:      map[string]string{"a": "x"},
+			b:      map[string]string{"a": "y"},
+			wanted: false,
+		},
+		{
+			name:   "different key test",
+			a:      map[string]string{"a": "x"},
+			b:      map[string]string{"b": "x"},
+			wanted: false,
+		},
+		{
+			name:   "different length test",
+			a:      map[string]string{"a": "x"},
+			b:      map[string]string{},
+			wanted: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualFunc(tt.a, tt.b, strings.EqualFold); got != tt.wanted {
+				t.Errorf("EqualFunc() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
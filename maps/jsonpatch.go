@@ -0,0 +1,357 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OperationType is the type of a JSON Patch operation.
+type OperationType string
+
+// JSON Patch operation types defined in RFC 6902.
+const (
+	OperationAdd     OperationType = "add"
+	OperationRemove  OperationType = "remove"
+	OperationReplace OperationType = "replace"
+	OperationMove    OperationType = "move"
+	OperationCopy    OperationType = "copy"
+	OperationTest    OperationType = "test"
+)
+
+// Operation is a single RFC 6902 JSON Patch operation.
+type Operation struct {
+	Op    OperationType `json:"op"`
+	Path  string        `json:"path"`
+	From  string        `json:"from,omitempty"`
+	Value interface{}   `json:"value,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface, the value is always emitted for the add, replace and test
+// operations since it is required by them even if it is null.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	if o.Op != OperationAdd && o.Op != OperationReplace && o.Op != OperationTest {
+		return json.Marshal(operation(o))
+	}
+	return json.Marshal(struct {
+		operation
+		Value interface{} `json:"value"`
+	}{operation: operation(o), Value: o.Value})
+}
+
+// Patch is an RFC 6902 JSON Patch document, it can be marshaled to or unmarshaled from JSON directly.
+type Patch []Operation
+
+// Apply applies the JSON Patch to the source map and returns the patched map, the source map is left untouched.
+// The operations are applied in order and the whole patch fails if any of them fails.
+//
+// eg:
+//
+//	src := map[string]interface{}{
+//		"a": map[string]interface{}{
+//			"b": 1,
+//		},
+//	}
+//	Apply(src, Patch{{Op: OperationReplace, Path: "/a/b", Value: 2}})
+//
+// result: map[a:map[b:2]]
+func Apply(src map[string]interface{}, patch Patch) (map[string]interface{}, error) {
+	var doc interface{} = deepCopyValue(src)
+	for i, op := range patch {
+		var err error
+		if doc, err = applyOperation(doc, op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %v", i, op.Op, op.Path, err)
+		}
+	}
+	result, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("patched document is %T, not an object", doc)
+	}
+	return result, nil
+}
+
+// CreatePatch generates a JSON Patch that transforms the original map into the modified one.
+// Nested maps are compared recursively, other values (including arrays) are replaced as a whole when they differ.
+// The operations are sorted by key so that the output is deterministic.
+func CreatePatch(original, modified map[string]interface{}) Patch {
+	patch := make(Patch, 0)
+	return diffMaps(patch, nil, original, modified)
+}
+
+func diffMaps(patch Patch, prefix []string, original, modified map[string]interface{}) Patch {
+	keys := make([]string, 0, len(original)+len(modified))
+	for k := range original {
+		keys = append(keys, k)
+	}
+	for k := range modified {
+		if _, ok := original[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		tokens := append(append(make([]string, 0, len(prefix)+1), prefix...), k)
+		path := tokensToJSONPointer(tokens)
+		oldValue, inOriginal := original[k]
+		newValue, inModified := modified[k]
+		switch {
+		case !inModified:
+			patch = append(patch, Operation{Op: OperationRemove, Path: path})
+		case !inOriginal:
+			patch = append(patch, Operation{Op: OperationAdd, Path: path, Value: deepCopyValue(newValue)})
+		default:
+			oldMap, oldIsMap := oldValue.(map[string]interface{})
+			newMap, newIsMap := newValue.(map[string]interface{})
+			if oldIsMap && newIsMap {
+				patch = diffMaps(patch, tokens, oldMap, newMap)
+				continue
+			}
+			if !jsonEqual(oldValue, newValue) {
+				patch = append(patch, Operation{Op: OperationReplace, Path: path, Value: deepCopyValue(newValue)})
+			}
+		}
+	}
+	return patch
+}
+
+func applyOperation(doc interface{}, op Operation) (interface{}, error) {
+	tokens, err := parseJSONPointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case OperationAdd:
+		return addValue(doc, tokens, deepCopyValue(op.Value))
+	case OperationRemove:
+		doc, _, err = removeValue(doc, tokens)
+		return doc, err
+	case OperationReplace:
+		if len(tokens) == 0 {
+			return deepCopyValue(op.Value), nil
+		}
+		return update(doc, tokens, func(container interface{}, token string) (interface{}, error) {
+			switch c := container.(type) {
+			case map[string]interface{}:
+				if _, ok := c[token]; !ok {
+					return nil, fmt.Errorf("key %q does not exist", token)
+				}
+				c[token] = deepCopyValue(op.Value)
+				return c, nil
+			case []interface{}:
+				index, err := arrayIndex(token, len(c)-1)
+				if err != nil {
+					return nil, err
+				}
+				c[index] = deepCopyValue(op.Value)
+				return c, nil
+			default:
+				return nil, fmt.Errorf("cannot replace %q in %T", token, container)
+			}
+		})
+	case OperationMove:
+		from, err := parseJSONPointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.From == op.Path {
+			return doc, nil
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("cannot move %q into one of its children", op.From)
+		}
+		doc, value, err := removeValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, tokens, value)
+	case OperationCopy:
+		from, err := parseJSONPointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, tokens, deepCopyValue(value))
+	case OperationTest:
+		value, err := getValue(doc, tokens)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(value, op.Value) {
+			return nil, fmt.Errorf("value %v does not equal %v", value, op.Value)
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", op.Op)
+	}
+}
+
+// update walks down the document following the tokens and replaces the container that holds the last token with
+// the result of fn, the parent containers are updated accordingly.
+func update(
+	doc interface{}, tokens []string, fn func(container interface{}, token string) (interface{}, error),
+) (interface{}, error) {
+	if len(tokens) == 1 {
+		return fn(doc, tokens[0])
+	}
+	child, err := getChild(doc, tokens[0])
+	if err != nil {
+		return nil, err
+	}
+	newChild, err := update(child, tokens[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+	switch c := doc.(type) {
+	case map[string]interface{}:
+		c[tokens[0]] = newChild
+	case []interface{}:
+		// getChild has already validated the index
+		index, _ := strconv.Atoi(tokens[0])
+		c[index] = newChild
+	}
+	return doc, nil
+}
+
+func getChild(container interface{}, token string) (interface{}, error) {
+	switch c := container.(type) {
+	case map[string]interface{}:
+		value, ok := c[token]
+		if !ok {
+			return nil, fmt.Errorf("key %q does not exist", token)
+		}
+		return value, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		return c[index], nil
+	default:
+		return nil, fmt.Errorf("cannot get %q from %T", token, container)
+	}
+}
+
+func getValue(doc interface{}, tokens []string) (interface{}, error) {
+	var err error
+	for _, token := range tokens {
+		if doc, err = getChild(doc, token); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+func addValue(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return update(doc, tokens, func(container interface{}, token string) (interface{}, error) {
+		switch c := container.(type) {
+		case map[string]interface{}:
+			c[token] = value
+			return c, nil
+		case []interface{}:
+			if token == "-" {
+				return append(c, value), nil
+			}
+			index, err := arrayIndex(token, len(c))
+			if err != nil {
+				return nil, err
+			}
+			result := make([]interface{}, 0, len(c)+1)
+			result = append(result, c[:index]...)
+			result = append(result, value)
+			return append(result, c[index:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot add %q to %T", token, container)
+		}
+	})
+}
+
+func removeValue(doc interface{}, tokens []string) (interface{}, interface{}, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the whole document")
+	}
+	var removed interface{}
+	doc, err := update(doc, tokens, func(container interface{}, token string) (interface{}, error) {
+		switch c := container.(type) {
+		case map[string]interface{}:
+			value, ok := c[token]
+			if !ok {
+				return nil, fmt.Errorf("key %q does not exist", token)
+			}
+			removed = value
+			delete(c, token)
+			return c, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			removed = c[index]
+			result := make([]interface{}, 0, len(c)-1)
+			result = append(result, c[:index]...)
+			return append(result, c[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove %q from %T", token, container)
+		}
+	})
+	return doc, removed, err
+}
+
+// arrayIndex parses the array index token and checks that it is in the range [0, upper].
+func arrayIndex(token string, upper int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if index < 0 || index > upper {
+		return 0, fmt.Errorf("array index %d out of bounds", index)
+	}
+	return index, nil
+}
+
+// jsonEqual determines whether two values are equal once encoded to JSON, so that eg: int 1 and float64 1 are equal.
+func jsonEqual(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	aData, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bData, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(aData) == string(bData)
+}
+
+// deepCopyValue returns a deep copy of the nested maps and slices in the value, other values are copied as is.
+func deepCopyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			result[k] = deepCopyValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = deepCopyValue(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,219 @@
+package maps
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	newSrc := func() map[string]interface{} {
+		return map[string]interface{}{
+			"a": map[string]interface{}{
+				"b": 1,
+			},
+			"list": []interface{}{"x", "y"},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		patch  Patch
+		isErr  bool
+		wanted map[string]interface{}
+	}{
+		{
+			name: "add test",
+			patch: Patch{
+				{Op: OperationAdd, Path: "/a/c", Value: 2},
+				{Op: OperationAdd, Path: "/list/1", Value: "z"},
+				{Op: OperationAdd, Path: "/list/-", Value: "end"},
+			},
+			wanted: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": 1,
+					"c": 2,
+				},
+				"list": []interface{}{"x", "z", "y", "end"},
+			},
+		},
+		{
+			name: "remove test",
+			patch: Patch{
+				{Op: OperationRemove, Path: "/a/b"},
+				{Op: OperationRemove, Path: "/list/0"},
+			},
+			wanted: map[string]interface{}{
+				"a":    map[string]interface{}{},
+				"list": []interface{}{"y"},
+			},
+		},
+		{
+			name: "replace test",
+			patch: Patch{
+				{Op: OperationReplace, Path: "/a/b", Value: 2},
+				{Op: OperationReplace, Path: "/list/1", Value: "z"},
+			},
+			wanted: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": 2,
+				},
+				"list": []interface{}{"x", "z"},
+			},
+		},
+		{
+			name: "move and copy test",
+			patch: Patch{
+				{Op: OperationCopy, From: "/a/b", Path: "/c"},
+				{Op: OperationMove, From: "/list", Path: "/a/list"},
+			},
+			wanted: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b":    1,
+					"list": []interface{}{"x", "y"},
+				},
+				"c": 1,
+			},
+		},
+		{
+			name: "test operation test",
+			patch: Patch{
+				{Op: OperationTest, Path: "/a/b", Value: float64(1)},
+			},
+			wanted: newSrc(),
+		},
+		{
+			name: "failed test operation test",
+			patch: Patch{
+				{Op: OperationTest, Path: "/a/b", Value: 2},
+			},
+			isErr: true,
+		},
+		{
+			name: "replace missing key test",
+			patch: Patch{
+				{Op: OperationReplace, Path: "/a/c", Value: 2},
+			},
+			isErr: true,
+		},
+		{
+			name: "index out of bounds test",
+			patch: Patch{
+				{Op: OperationAdd, Path: "/list/3", Value: "z"},
+			},
+			isErr: true,
+		},
+		{
+			name: "move into child test",
+			patch: Patch{
+				{Op: OperationMove, From: "/a", Path: "/a/d"},
+			},
+			isErr: true,
+		},
+		{
+			name: "unsupported operation test",
+			patch: Patch{
+				{Op: "merge", Path: "/a"},
+			},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := newSrc()
+			got, err := Apply(src, tt.patch)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s Apply() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("Apply() = %v, want %v", got, tt.wanted)
+			}
+			if !reflect.DeepEqual(src, newSrc()) {
+				t.Errorf("Apply() modified the source map: %v", src)
+			}
+		})
+	}
+}
+
+func TestCreatePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original map[string]interface{}
+		modified map[string]interface{}
+		wanted   string
+	}{
+		{
+			name: "normal test",
+			original: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": 1,
+					"c": 1,
+				},
+				"d":    "x",
+				"list": []interface{}{1},
+			},
+			modified: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": 2,
+				},
+				"e":    "y",
+				"list": []interface{}{1, 2},
+			},
+			wanted: `[{"op":"replace","path":"/a/b","value":2},{"op":"remove","path":"/a/c"},` +
+				`{"op":"remove","path":"/d"},{"op":"add","path":"/e","value":"y"},` +
+				`{"op":"replace","path":"/list","value":[1,2]}]`,
+		},
+		{
+			name: "escape test",
+			original: map[string]interface{}{
+				"labels": map[string]interface{}{},
+			},
+			modified: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"app/name": "x",
+				},
+			},
+			wanted: `[{"op":"add","path":"/labels/app~1name","value":"x"}]`,
+		},
+		{
+			name: "null value test",
+			original: map[string]interface{}{
+				"a": 1,
+			},
+			modified: map[string]interface{}{
+				"a": nil,
+			},
+			wanted: `[{"op":"replace","path":"/a","value":null}]`,
+		},
+		{
+			name: "equal test",
+			original: map[string]interface{}{
+				"a": 1,
+			},
+			modified: map[string]interface{}{
+				"a": float64(1),
+			},
+			wanted: `[]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := CreatePatch(tt.original, tt.modified)
+			data, _ := json.Marshal(patch)
+			if string(data) != tt.wanted {
+				t.Errorf("CreatePatch() = %s, want %s", data, tt.wanted)
+			}
+
+			got, err := Apply(tt.original, patch)
+			if err != nil {
+				t.Fatalf("%s Apply() unexpected error: %v", tt.name, err)
+			}
+			if !jsonEqual(got, tt.modified) {
+				t.Errorf("Apply(CreatePatch()) = %v, want %v", got, tt.modified)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package maps
+
+import (
+	"fmt"
+	"strings"
+)
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// PathToJSONPointer converts a nested field path (eg: a.b.c) to an RFC 6901 JSON Pointer (eg: /a/b/c).
+// An empty path refers to the whole document.
+func PathToJSONPointer(path string) string {
+	if path == "" {
+		return ""
+	}
+	return tokensToJSONPointer(strings.Split(path, "."))
+}
+
+// JSONPointerToPath converts an RFC 6901 JSON Pointer (eg: /a/b/c) to a nested field path (eg: a.b.c).
+// An error is returned if the pointer is invalid or any of its reference tokens contains a dot, which cannot be
+// represented in a nested field path.
+func JSONPointerToPath(pointer string) (string, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return "", err
+	}
+	for _, token := range tokens {
+		if strings.Contains(token, ".") {
+			return "", fmt.Errorf("reference token %q of JSON pointer %q contains a dot", token, pointer)
+		}
+	}
+	return strings.Join(tokens, "."), nil
+}
+
+// tokensToJSONPointer builds a JSON Pointer from unescaped reference tokens.
+func tokensToJSONPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(pointerEscaper.Replace(token))
+	}
+	return b.String()
+}
+
+// parseJSONPointer splits a JSON Pointer into unescaped reference tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("JSON pointer %q must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,77 @@
+package maps
+
+import (
+	"testing"
+)
+
+func TestPathToJSONPointer(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		wanted string
+	}{
+		{
+			name:   "normal test",
+			path:   "a.b.c",
+			wanted: "/a/b/c",
+		},
+		{
+			name:   "escape test",
+			path:   "metadata.annotations.example.com~1/name",
+			wanted: "/metadata/annotations/example/com~01~1name",
+		},
+		{
+			name:   "root test",
+			path:   "",
+			wanted: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PathToJSONPointer(tt.path); got != tt.wanted {
+				t.Errorf("PathToJSONPointer() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestJSONPointerToPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		pointer string
+		isErr   bool
+		wanted  string
+	}{
+		{
+			name:    "normal test",
+			pointer: "/a/b/c",
+			wanted:  "a.b.c",
+		},
+		{
+			name:    "unescape test",
+			pointer: "/labels/app~1name~0x",
+			wanted:  "labels.app/name~x",
+		},
+		{
+			name:    "invalid pointer test",
+			pointer: "a/b",
+			isErr:   true,
+		},
+		{
+			name:    "dot test",
+			pointer: "/metadata/labels/example.com",
+			isErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONPointerToPath(tt.pointer)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s JSONPointerToPath() unexpected error: %v", tt.name, err)
+			}
+			if got != tt.wanted {
+				t.Errorf("JSONPointerToPath() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
@@ -13,7 +13,10 @@ import (
 //
 // src: map[a:map[b:1]]
 func SetNestedField(src map[string]interface{}, key string, value interface{}) {
-	keys := strings.Split(key, ".")
+	setNestedField(src, strings.Split(key, "."), value)
+}
+
+func setNestedField(src map[string]interface{}, keys []string, value interface{}) {
 	l := len(keys) - 1
 	currentMap := src
 	// Move to the target level
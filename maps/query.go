@@ -0,0 +1,478 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Match is a value selected by Query along with its concrete path (eg: spec.containers[0].image).
+type Match struct {
+	Path  string
+	Value interface{}
+
+	// location is the list of map keys (string) and array indices (int) leading to the value.
+	location []interface{}
+}
+
+type stepType int
+
+const (
+	stepChild stepType = iota
+	stepIndex
+	stepWildcard
+	stepDescendants
+	stepFilter
+)
+
+type filterOperator string
+
+const (
+	filterEqual    filterOperator = "=="
+	filterNotEqual filterOperator = "!="
+)
+
+// step is one compiled segment of a query.
+type step struct {
+	typ   stepType
+	key   string
+	index int
+
+	// filter fields
+	filterPath []string
+	operator   filterOperator
+	value      interface{}
+}
+
+// Query selects all the values in the source map matched by a JSONPath-like query, the matches are returned in a
+// deterministic order (map keys are sorted). The supported syntax is:
+//
+//	a.b            child fields, the leading $ or $. is optional
+//	a['b.c']       child field whose key contains special characters
+//	a[0], a[-1]    array element, negative indices count from the end
+//	a.*, a[*]      all the children of a map or an array
+//	..name         name fields at any depth
+//	a[?(@.b=='x')] children of a whose nested field b equals (==) or does not equal (!=) the JSON literal
+//
+// eg:
+//
+//	Query(pod, "spec.containers[*].image")
+//	Query(pod, "spec.containers[?(@.name=='nginx')].image")
+//	Query(pod, "..name")
+func Query(src map[string]interface{}, query string) ([]Match, error) {
+	steps, err := compileQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []Match{{Value: src}}
+	for _, s := range steps {
+		next := make([]Match, 0)
+		for _, m := range matches {
+			next = s.apply(next, m)
+		}
+		matches = next
+	}
+	return matches, nil
+}
+
+// SetAll sets the value of every field matched by the query, returns the number of updated fields.
+// Fields that do not exist are not created.
+//
+// eg:
+//
+//	SetAll(pod, "spec.containers[*].image", "nginx:latest")
+func SetAll(src map[string]interface{}, query string, value interface{}) (int, error) {
+	return UpdateAll(src, query, func(Match) interface{} {
+		return value
+	})
+}
+
+// UpdateAll replaces every field matched by the query with the result of fn, returns the number of updated fields.
+// When a matched field is nested in another matched one (eg: the query ..*), only the outermost field is updated.
+//
+// eg:
+//
+//	UpdateAll(pod, "spec.containers[*].image", func(m Match) interface{} {
+//		return strings.Replace(m.Value.(string), "docker.io", "mirror.local", 1)
+//	})
+func UpdateAll(src map[string]interface{}, query string, fn func(Match) interface{}) (int, error) {
+	matches, err := Query(src, query)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range matches {
+		if len(m.location) == 0 {
+			return 0, fmt.Errorf("query %q selects the whole map", query)
+		}
+	}
+
+	count := 0
+	for _, m := range outermostMatches(matches) {
+		var container interface{} = src
+		found := true
+		for _, loc := range m.location[:len(m.location)-1] {
+			if container, found = locate(container, loc); !found {
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		value := fn(m)
+		switch last := m.location[len(m.location)-1].(type) {
+		case string:
+			obj, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			obj[last] = value
+		case int:
+			arr, ok := container.([]interface{})
+			if !ok || last >= len(arr) {
+				continue
+			}
+			arr[last] = value
+		}
+		count++
+	}
+	return count, nil
+}
+
+// outermostMatches removes the matches nested in (or equal to) another match, the order is kept.
+func outermostMatches(matches []Match) []Match {
+	result := make([]Match, 0, len(matches))
+	for i, m := range matches {
+		nested := false
+		for j, other := range matches {
+			if i == j || len(other.location) > len(m.location) || !isLocationPrefix(other.location, m.location) {
+				continue
+			}
+			// equal locations keep the first match
+			if len(other.location) < len(m.location) || j < i {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+func isLocationPrefix(prefix, location []interface{}) bool {
+	for i := range prefix {
+		if prefix[i] != location[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// locate returns the child of the container at the location, false is returned if it does not exist.
+func locate(container interface{}, loc interface{}) (interface{}, bool) {
+	switch l := loc.(type) {
+	case string:
+		obj, ok := container.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		value, ok := obj[l]
+		return value, ok
+	case int:
+		arr, ok := container.([]interface{})
+		if !ok || l >= len(arr) {
+			return nil, false
+		}
+		return arr[l], true
+	}
+	return nil, false
+}
+
+func (s step) apply(result []Match, m Match) []Match {
+	switch s.typ {
+	case stepChild:
+		if obj, ok := m.Value.(map[string]interface{}); ok {
+			if value, ok := obj[s.key]; ok {
+				result = append(result, m.child(s.key, value))
+			}
+		}
+	case stepIndex:
+		if arr, ok := m.Value.([]interface{}); ok {
+			index := s.index
+			if index < 0 {
+				index += len(arr)
+			}
+			if index >= 0 && index < len(arr) {
+				result = append(result, m.child(index, arr[index]))
+			}
+		}
+	case stepWildcard:
+		result = append(result, children(m)...)
+	case stepDescendants:
+		result = append(result, m)
+		for _, child := range children(m) {
+			result = s.apply(result, child)
+		}
+	case stepFilter:
+		for _, child := range children(m) {
+			if s.matchFilter(child.Value) {
+				result = append(result, child)
+			}
+		}
+	}
+	return result
+}
+
+func (s step) matchFilter(value interface{}) bool {
+	for _, k := range s.filterPath {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return s.operator == filterNotEqual
+		}
+		if value, ok = obj[k]; !ok {
+			return s.operator == filterNotEqual
+		}
+	}
+	equal := jsonEqual(value, s.value)
+	if s.operator == filterNotEqual {
+		return !equal
+	}
+	return equal
+}
+
+// children returns the direct children of the matched value, map keys are sorted.
+func children(m Match) []Match {
+	switch v := m.Value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		result := make([]Match, 0, len(v))
+		for _, k := range keys {
+			result = append(result, m.child(k, v[k]))
+		}
+		return result
+	case []interface{}:
+		result := make([]Match, 0, len(v))
+		for i, item := range v {
+			result = append(result, m.child(i, item))
+		}
+		return result
+	}
+	return nil
+}
+
+func (m Match) child(loc interface{}, value interface{}) Match {
+	location := append(append(make([]interface{}, 0, len(m.location)+1), m.location...), loc)
+	path := m.Path
+	switch l := loc.(type) {
+	case string:
+		switch {
+		case strings.ContainsAny(l, ".[]'\"*@$ ") || l == "":
+			path += "['" + strings.ReplaceAll(l, "'", "\\'") + "']"
+		case path == "":
+			path = l
+		default:
+			path += "." + l
+		}
+	case int:
+		path += "[" + strconv.Itoa(l) + "]"
+	}
+	return Match{Path: path, Value: value, location: location}
+}
+
+// compileQuery parses the query into steps.
+func compileQuery(query string) ([]step, error) {
+	q := strings.TrimPrefix(query, "$")
+	steps := make([]step, 0)
+	for i := 0; i < len(q); {
+		switch {
+		case strings.HasPrefix(q[i:], ".."):
+			steps = append(steps, step{typ: stepDescendants})
+			i += 2
+			if i < len(q) && q[i] == '[' {
+				continue
+			}
+			s, n, err := parseKey(q[i:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid query %q: %v", query, err)
+			}
+			steps = append(steps, s)
+			i += n
+		case q[i] == '.':
+			i++
+			s, n, err := parseKey(q[i:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid query %q: %v", query, err)
+			}
+			steps = append(steps, s)
+			i += n
+		case q[i] == '[':
+			s, n, err := parseBracket(q[i:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid query %q: %v", query, err)
+			}
+			steps = append(steps, s)
+			i += n
+		case i == 0:
+			s, n, err := parseKey(q)
+			if err != nil {
+				return nil, fmt.Errorf("invalid query %q: %v", query, err)
+			}
+			steps = append(steps, s)
+			i += n
+		default:
+			return nil, fmt.Errorf("invalid query %q: unexpected character %q", query, q[i])
+		}
+	}
+	return steps, nil
+}
+
+// parseKey parses a bare key at the beginning of the input, returns the step and the number of bytes consumed.
+func parseKey(input string) (step, int, error) {
+	n := strings.IndexAny(input, ".[")
+	if n < 0 {
+		n = len(input)
+	}
+	key := input[:n]
+	if key == "" {
+		return step{}, 0, fmt.Errorf("empty key")
+	}
+	if key == "*" {
+		return step{typ: stepWildcard}, n, nil
+	}
+	return step{typ: stepChild, key: key}, n, nil
+}
+
+// parseBracket parses a bracket expression at the beginning of the input, returns the step and the number of
+// bytes consumed.
+func parseBracket(input string) (step, int, error) {
+	end := -1
+	var quote byte
+	for i := 1; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ']':
+			end = i
+		}
+		if end >= 0 {
+			break
+		}
+	}
+	if end < 0 {
+		return step{}, 0, fmt.Errorf("unclosed bracket")
+	}
+
+	expr := strings.TrimSpace(input[1:end])
+	switch {
+	case expr == "*":
+		return step{typ: stepWildcard}, end + 1, nil
+	case strings.HasPrefix(expr, "?"):
+		s, err := parseFilter(expr)
+		return s, end + 1, err
+	case len(expr) >= 2 && (expr[0] == '\'' || expr[0] == '"') && expr[len(expr)-1] == expr[0]:
+		key, err := unquote(expr)
+		if err != nil {
+			return step{}, 0, err
+		}
+		return step{typ: stepChild, key: key}, end + 1, nil
+	default:
+		index, err := strconv.Atoi(expr)
+		if err != nil {
+			return step{}, 0, fmt.Errorf("invalid array index %q", expr)
+		}
+		return step{typ: stepIndex, index: index}, end + 1, nil
+	}
+}
+
+// parseFilter parses a filter expression like ?(@.a.b=='x').
+func parseFilter(expr string) (step, error) {
+	body := strings.TrimSpace(strings.TrimPrefix(expr, "?"))
+	if !strings.HasPrefix(body, "(") || !strings.HasSuffix(body, ")") {
+		return step{}, fmt.Errorf("invalid filter %q", expr)
+	}
+	body = body[1 : len(body)-1]
+
+	i, operator := findFilterOperator(body)
+	if i < 0 {
+		return step{}, fmt.Errorf("filter %q must be an == or != comparison", expr)
+	}
+	parts := []string{body[:i], body[i+len(operator):]}
+
+	left := strings.TrimSpace(parts[0])
+	if !strings.HasPrefix(left, "@.") || len(left) == 2 {
+		return step{}, fmt.Errorf("filter %q must compare a field of @", expr)
+	}
+	right := strings.TrimSpace(parts[1])
+	var value interface{}
+	if len(right) >= 2 && right[0] == '\'' && right[len(right)-1] == '\'' {
+		s, err := unquote(right)
+		if err != nil {
+			return step{}, err
+		}
+		value = s
+	} else if err := json.Unmarshal([]byte(right), &value); err != nil {
+		return step{}, fmt.Errorf("invalid filter value %q: %v", right, err)
+	}
+
+	return step{
+		typ:        stepFilter,
+		filterPath: strings.Split(left[2:], "."),
+		operator:   operator,
+		value:      value,
+	}, nil
+}
+
+// findFilterOperator returns the position of the first == or != operator outside the quoted strings of the filter,
+// -1 is returned if there is none.
+func findFilterOperator(body string) (int, filterOperator) {
+	var quote byte
+	for i := 0; i+1 < len(body); i++ {
+		c := body[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case body[i:i+2] == string(filterEqual):
+			return i, filterEqual
+		case body[i:i+2] == string(filterNotEqual):
+			return i, filterNotEqual
+		}
+	}
+	return -1, ""
+}
+
+// unquote removes the surrounding single or double quotes of the string and unescapes the quotes inside it.
+func unquote(s string) (string, error) {
+	q := s[0]
+	body := s[1 : len(s)-1]
+	var b strings.Builder
+	for i := 0; i < len(body); i++ {
+		if body[i] == '\\' && i+1 < len(body) {
+			i++
+		} else if body[i] == q {
+			return "", fmt.Errorf("unescaped quote in %s", s)
+		}
+		b.WriteByte(body[i])
+	}
+	return b.String(), nil
+}
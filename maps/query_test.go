@@ -0,0 +1,195 @@
+package maps
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newPod() map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "pod",
+			"labels": map[string]interface{}{
+				"app.kubernetes.io/name": "web",
+			},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name":  "nginx",
+					"image": "nginx:1.0",
+				},
+				map[string]interface{}{
+					"name":  "sidecar",
+					"image": "envoy:1.0",
+				},
+			},
+		},
+	}
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		isErr  bool
+		wanted map[string]interface{}
+	}{
+		{
+			name:  "child test",
+			query: "$.metadata.name",
+			wanted: map[string]interface{}{
+				"metadata.name": "pod",
+			},
+		},
+		{
+			name:  "wildcard test",
+			query: "spec.containers[*].image",
+			wanted: map[string]interface{}{
+				"spec.containers[0].image": "nginx:1.0",
+				"spec.containers[1].image": "envoy:1.0",
+			},
+		},
+		{
+			name:  "index test",
+			query: "spec.containers[-1].name",
+			wanted: map[string]interface{}{
+				"spec.containers[1].name": "sidecar",
+			},
+		},
+		{
+			name:  "quoted key test",
+			query: "metadata.labels['app.kubernetes.io/name']",
+			wanted: map[string]interface{}{
+				"metadata.labels['app.kubernetes.io/name']": "web",
+			},
+		},
+		{
+			name:  "recursive descent test",
+			query: "..name",
+			wanted: map[string]interface{}{
+				"metadata.name":           "pod",
+				"spec.containers[0].name": "nginx",
+				"spec.containers[1].name": "sidecar",
+			},
+		},
+		{
+			name:  "filter test",
+			query: `spec.containers[?(@.name=="nginx")].image`,
+			wanted: map[string]interface{}{
+				"spec.containers[0].image": "nginx:1.0",
+			},
+		},
+		{
+			name:  "not equal filter test",
+			query: "spec.containers[?(@.name != 'nginx')].image",
+			wanted: map[string]interface{}{
+				"spec.containers[1].image": "envoy:1.0",
+			},
+		},
+		{
+			name:  "quoted operator filter test",
+			query: "spec.containers[?(@.name!='x==y')].name",
+			wanted: map[string]interface{}{
+				"spec.containers[0].name": "nginx",
+				"spec.containers[1].name": "sidecar",
+			},
+		},
+		{
+			name:   "not found test",
+			query:  "spec.volumes[*]",
+			wanted: map[string]interface{}{},
+		},
+		{
+			name:  "unclosed bracket test",
+			query: "spec.containers[0",
+			isErr: true,
+		},
+		{
+			name:  "invalid index test",
+			query: "spec.containers[a]",
+			isErr: true,
+		},
+		{
+			name:  "invalid filter test",
+			query: "spec.containers[?(name)]",
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := Query(newPod(), tt.query)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s Query() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			got := make(map[string]interface{}, len(matches))
+			for _, m := range matches {
+				got[m.Path] = m.Value
+			}
+			if !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("Query() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestSetAll(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		value  interface{}
+		isErr  bool
+		wanted int
+		check  string
+	}{
+		{
+			name:   "normal test",
+			query:  "spec.containers[*].image",
+			value:  "busybox",
+			wanted: 2,
+			check:  "spec.containers[1].image",
+		},
+		{
+			name:   "quoted key test",
+			query:  "metadata.labels['app.kubernetes.io/name']",
+			value:  "api",
+			wanted: 1,
+			check:  "metadata.labels['app.kubernetes.io/name']",
+		},
+		{
+			name:   "nested matches test",
+			query:  "..*",
+			value:  "x",
+			wanted: 2,
+			check:  "spec",
+		},
+		{
+			name:  "root test",
+			query: "$",
+			value: "x",
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := newPod()
+			got, err := SetAll(pod, tt.query, tt.value)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s SetAll() unexpected error: %v", tt.name, err)
+			}
+			if got != tt.wanted {
+				t.Errorf("SetAll() = %v, want %v", got, tt.wanted)
+			}
+			if tt.isErr {
+				return
+			}
+			matches, _ := Query(pod, tt.check)
+			if len(matches) != 1 || matches[0].Value != tt.value {
+				t.Errorf("SetAll() did not update %s: %v", tt.check, matches)
+			}
+		})
+	}
+}
@@ -0,0 +1,593 @@
+package maps
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	quantityType        = reflect.TypeOf(resource.Quantity{})
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// StructOptions defines options needed to convert a nested map to a struct.
+type StructOptions struct {
+	strict bool
+}
+
+// WithStrict sets whether to report the keys of the map that do not match any field of the struct.
+// The default value is false.
+func WithStrict(strict bool) func(*StructOptions) {
+	return func(o *StructOptions) {
+		o.strict = strict
+	}
+}
+
+// FromStruct converts a struct (or a pointer to a struct) into a nested map using the json tags of its fields.
+// The json tag options omitempty and inline are respected and embedded structs are inlined, time.Duration and
+// resource.Quantity are written as strings, integers as int64 and floats as float64, so that the result can be used
+// as the content of an unstructured object.
+func FromStruct(v interface{}) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("cannot convert a nil %T", v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot convert %T, it is not a struct", v)
+	}
+
+	result, err := fromValue(rv, "")
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]interface{}), nil
+}
+
+// ToStruct converts a nested map into the struct pointed to by v using the json tags of its fields, it is the
+// inverse of FromStruct. In strict mode all the keys that do not match any field are reported.
+//
+// eg:
+//
+//	config := &Config{}
+//	ToStruct(src, config, WithStrict(true))
+func ToStruct(src map[string]interface{}, v interface{}, options ...func(*StructOptions)) error {
+	opts := &StructOptions{}
+	for _, f := range options {
+		f(opts)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("cannot convert to %T, it is not a non-nil pointer", v)
+	}
+	if rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot convert to %T, it is not a pointer to a struct", v)
+	}
+
+	c := &structConverter{strict: opts.strict}
+	if err := c.toValue(src, rv.Elem(), ""); err != nil {
+		return err
+	}
+	return errors.Join(c.unknownFields...)
+}
+
+// structField is a field of a struct that is mapped to a key of the map.
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// structFields returns the fields of the struct type, the fields of inline and embedded structs are promoted unless
+// they are shadowed by a field with the same name in the outer struct.
+func structFields(t reflect.Type) []structField {
+	fields := make([]structField, 0, t.NumField())
+	names := make(map[string]bool)
+	inlined := make([]structField, 0)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fieldType := f.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		isInline := strings.Contains(","+opts+",", ",inline,") || (f.Anonymous && name == "")
+		if isInline && fieldType.Kind() == reflect.Struct {
+			for _, sf := range structFields(fieldType) {
+				sf.index = append([]int{i}, sf.index...)
+				inlined = append(inlined, sf)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+		fields = append(fields, structField{
+			name:      name,
+			index:     []int{i},
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+	for _, sf := range inlined {
+		if !names[sf.name] {
+			names[sf.name] = true
+			fields = append(fields, sf)
+		}
+	}
+	return fields
+}
+
+func fromValue(v reflect.Value, path string) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	switch v.Type() {
+	case durationType:
+		return time.Duration(v.Int()).String(), nil
+	case quantityType:
+		q := v.Interface().(resource.Quantity)
+		return q.String(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return fromValue(v.Elem(), path)
+	}
+
+	if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) ||
+		reflect.PointerTo(v.Type()).Implements(jsonMarshalerType) {
+		return marshalerValue(v, path)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		result := make(map[string]interface{})
+		for _, f := range structFields(v.Type()) {
+			fv, ok := fieldByIndex(v, f.index, false)
+			if !ok || (f.omitEmpty && isEmptyValue(fv)) {
+				continue
+			}
+			value, err := fromValue(fv, joinPath(path, f.name))
+			if err != nil {
+				return nil, err
+			}
+			result[f.name] = value
+		}
+		return result, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		result := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := mapKeyString(iter.Key())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+			value, err := fromValue(iter.Value(), joinPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+		}
+		return result, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+		}
+		fallthrough
+	case reflect.Array:
+		result := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			value, err := fromValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%s: value %d overflows int64", path, v.Uint())
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	default:
+		return nil, fmt.Errorf("%s: unsupported type %s", path, v.Type())
+	}
+}
+
+// marshalerValue converts a value that knows how to marshal itself to JSON (eg: metav1.Time) into a map value.
+func marshalerValue(v reflect.Value, path string) (interface{}, error) {
+	value := v.Interface()
+	if !v.Type().Implements(jsonMarshalerType) && !v.Type().Implements(textMarshalerType) {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		value = ptr.Interface()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	var result interface{}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	if err = decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return normalizeNumbers(result), nil
+}
+
+// normalizeNumbers converts the json.Number values to int64 or float64.
+func normalizeNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = normalizeNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+	}
+	return value
+}
+
+type structConverter struct {
+	strict        bool
+	unknownFields []error
+}
+
+func (c *structConverter) toValue(src interface{}, v reflect.Value, path string) error {
+	if src == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Type() {
+	case durationType:
+		switch s := src.(type) {
+		case string:
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+			v.SetInt(int64(d))
+			return nil
+		default:
+			return c.toNumber(src, v, path)
+		}
+	case quantityType:
+		var s string
+		switch value := src.(type) {
+		case string:
+			s = value
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			s = fmt.Sprint(value)
+		default:
+			return typeError(src, v, path)
+		}
+		q, err := resource.ParseQuantity(s)
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		v.Set(reflect.ValueOf(q))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return c.toValue(src, v.Elem(), path)
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fmt.Errorf("%s: unsupported type %s", path, v.Type())
+		}
+		v.Set(reflect.ValueOf(deepCopyValue(src)))
+		return nil
+	}
+
+	// the encoding.TextMarshaler values (eg: net.IP) are written as strings by FromStruct
+	if s, ok := src.(string); ok && reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) &&
+		!reflect.PointerTo(v.Type()).Implements(jsonUnmarshalerType) {
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		return nil
+	}
+	if reflect.PointerTo(v.Type()).Implements(jsonUnmarshalerType) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		if err = json.Unmarshal(data, v.Addr().Interface()); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			return typeError(src, v, path)
+		}
+		fields := make(map[string]structField)
+		for _, f := range structFields(v.Type()) {
+			fields[f.name] = f
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			f, ok := fields[k]
+			if !ok {
+				if c.strict {
+					c.unknownFields = append(c.unknownFields, fmt.Errorf("%s: unknown field", joinPath(path, k)))
+				}
+				continue
+			}
+			fv, ok := fieldByIndex(v, f.index, true)
+			if !ok {
+				return fmt.Errorf("%s: cannot set embedded pointer to unexported struct", joinPath(path, k))
+			}
+			if err := c.toValue(m[k], fv, joinPath(path, k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			return typeError(src, v, path)
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), len(m)))
+		}
+		for k, item := range m {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := setMapKey(k, key); err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+			value := reflect.New(v.Type().Elem()).Elem()
+			if err := c.toValue(item, value, joinPath(path, k)); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, value)
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if s, ok := src.(string); ok && v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			data, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+			v.SetBytes(data)
+			return nil
+		}
+		items, ok := src.([]interface{})
+		if !ok {
+			return typeError(src, v, path)
+		}
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), len(items), len(items)))
+		} else if len(items) != v.Len() {
+			return fmt.Errorf("%s: cannot convert %d items to %s", path, len(items), v.Type())
+		}
+		for i, item := range items {
+			if err := c.toValue(item, v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.String:
+		s, ok := src.(string)
+		if !ok {
+			return typeError(src, v, path)
+		}
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, ok := src.(bool)
+		if !ok {
+			return typeError(src, v, path)
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return c.toNumber(src, v, path)
+	default:
+		return fmt.Errorf("%s: unsupported type %s", path, v.Type())
+	}
+}
+
+func (c *structConverter) toNumber(src interface{}, v reflect.Value, path string) error {
+	sv := reflect.ValueOf(src)
+	var f float64
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(sv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(sv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = sv.Float()
+	default:
+		return typeError(src, v, path)
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = sv.Int()
+		default:
+			if f != math.Trunc(f) || f > math.MaxInt64 || f < math.MinInt64 {
+				return typeError(src, v, path)
+			}
+			i = int64(f)
+		}
+		if v.OverflowInt(i) {
+			return typeError(src, v, path)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if f < 0 || f != math.Trunc(f) {
+			return typeError(src, v, path)
+		}
+		var u uint64
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			u = uint64(sv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			u = sv.Uint()
+		default:
+			u = uint64(f)
+		}
+		if v.OverflowUint(u) {
+			return typeError(src, v, path)
+		}
+		v.SetUint(u)
+	default:
+		if v.OverflowFloat(f) {
+			return typeError(src, v, path)
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+// fieldByIndex returns the nested field of the struct, the nil embedded struct pointers on the way are allocated if
+// alloc is true, otherwise (or if they cannot be set) false is returned when one of them is nil.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isEmptyValue reports whether the value is empty according to the json omitempty semantics.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+func mapKeyString(key reflect.Value) (string, error) {
+	switch key.Kind() {
+	case reflect.String:
+		return key.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported map key type %s", key.Type())
+}
+
+func setMapKey(s string, key reflect.Value) error {
+	switch key.Kind() {
+	case reflect.String:
+		key.SetString(s)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, key.Type().Bits())
+		if err != nil {
+			return err
+		}
+		key.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, key.Type().Bits())
+		if err != nil {
+			return err
+		}
+		key.SetUint(u)
+		return nil
+	}
+	return fmt.Errorf("unsupported map key type %s", key.Type())
+}
+
+func typeError(src interface{}, v reflect.Value, path string) error {
+	return fmt.Errorf("%s: cannot convert %T to %s", path, src, v.Type())
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,283 @@
+package maps
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type testBase struct {
+	Name string `json:"name"`
+}
+
+type testLimits struct {
+	Memory resource.Quantity  `json:"memory"`
+	CPU    *resource.Quantity `json:"cpu,omitempty"`
+}
+
+type testConfig struct {
+	testBase `json:",inline"`
+	*TestMeta
+	Replicas int32             `json:"replicas"`
+	Timeout  time.Duration     `json:"timeout"`
+	Enabled  *bool             `json:"enabled,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Ports    []int             `json:"ports,omitempty"`
+	Limits   testLimits        `json:"limits"`
+	Ignored  string            `json:"-"`
+	Ratio    float64           `json:"ratio,omitempty"`
+}
+
+// TestMeta is exported so that ToStruct can allocate it when it is embedded as a pointer.
+type TestMeta struct {
+	Owner string `json:"owner,omitempty"`
+}
+
+func TestFromStruct(t *testing.T) {
+	enabled := true
+	cpu := resource.MustParse("500m")
+
+	tests := []struct {
+		name   string
+		obj    interface{}
+		isErr  bool
+		wanted map[string]interface{}
+	}{
+		{
+			name: "normal test",
+			obj: &testConfig{
+				testBase: testBase{Name: "test"},
+				TestMeta: &TestMeta{Owner: "infra"},
+				Replicas: 2,
+				Timeout:  time.Minute,
+				Enabled:  &enabled,
+				Labels:   map[string]string{"app": "web"},
+				Ports:    []int{80},
+				Limits: testLimits{
+					Memory: resource.MustParse("1Gi"),
+					CPU:    &cpu,
+				},
+				Ignored: "x",
+			},
+			wanted: map[string]interface{}{
+				"name":     "test",
+				"owner":    "infra",
+				"replicas": int64(2),
+				"timeout":  "1m0s",
+				"enabled":  true,
+				"labels":   map[string]interface{}{"app": "web"},
+				"ports":    []interface{}{int64(80)},
+				"limits": map[string]interface{}{
+					"memory": "1Gi",
+					"cpu":    "500m",
+				},
+			},
+		},
+		{
+			name: "omitempty test",
+			obj:  testConfig{},
+			wanted: map[string]interface{}{
+				"name":     "",
+				"replicas": int64(0),
+				"timeout":  "0s",
+				"limits": map[string]interface{}{
+					"memory": "0",
+				},
+			},
+		},
+		{
+			name:  "not struct test",
+			obj:   map[string]string{},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromStruct(tt.obj)
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s FromStruct() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("FromStruct() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestToStruct(t *testing.T) {
+	enabled := true
+	cpu := resource.MustParse("500m")
+
+	tests := []struct {
+		name   string
+		src    map[string]interface{}
+		strict bool
+		isErr  bool
+		wanted *testConfig
+	}{
+		{
+			name: "normal test",
+			src: map[string]interface{}{
+				"name":     "test",
+				"owner":    "infra",
+				"replicas": float64(2),
+				"timeout":  "1m",
+				"enabled":  true,
+				"labels":   map[string]interface{}{"app": "web"},
+				"ports":    []interface{}{int64(80)},
+				"limits": map[string]interface{}{
+					"memory": "1Gi",
+					"cpu":    "500m",
+				},
+				"unknown": 1,
+			},
+			wanted: &testConfig{
+				testBase: testBase{Name: "test"},
+				TestMeta: &TestMeta{Owner: "infra"},
+				Replicas: 2,
+				Timeout:  time.Minute,
+				Enabled:  &enabled,
+				Labels:   map[string]string{"app": "web"},
+				Ports:    []int{80},
+				Limits: testLimits{
+					Memory: resource.MustParse("1Gi"),
+					CPU:    &cpu,
+				},
+			},
+		},
+		{
+			name: "strict test",
+			src: map[string]interface{}{
+				"name": "test",
+				"limits": map[string]interface{}{
+					"mem": "1Gi",
+				},
+			},
+			strict: true,
+			isErr:  true,
+		},
+		{
+			name: "type mismatch test",
+			src: map[string]interface{}{
+				"replicas": "2",
+			},
+			isErr: true,
+		},
+		{
+			name: "fractional integer test",
+			src: map[string]interface{}{
+				"replicas": 1.5,
+			},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &testConfig{}
+			err := ToStruct(tt.src, got, WithStrict(tt.strict))
+			if tt.isErr != (err != nil) {
+				t.Errorf("%s ToStruct() unexpected error: %v", tt.name, err)
+			}
+			if tt.isErr {
+				return
+			}
+			if got.Limits.Memory.Cmp(tt.wanted.Limits.Memory) != 0 || got.Limits.CPU.Cmp(*tt.wanted.Limits.CPU) != 0 {
+				t.Errorf("ToStruct() limits = %v, want %v", got.Limits, tt.wanted.Limits)
+			}
+			got.Limits, tt.wanted.Limits = testLimits{}, testLimits{}
+			if !reflect.DeepEqual(got, tt.wanted) {
+				t.Errorf("ToStruct() = %+v, want %+v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestStructRoundTrip(t *testing.T) {
+	obj := &testConfig{
+		testBase: testBase{Name: "test"},
+		Replicas: 3,
+		Timeout:  90 * time.Second,
+		Ratio:    0.5,
+	}
+	m, err := FromStruct(obj)
+	if err != nil {
+		t.Fatalf("FromStruct() unexpected error: %v", err)
+	}
+	got := &testConfig{}
+	if err = ToStruct(m, got, WithStrict(true)); err != nil {
+		t.Fatalf("ToStruct() unexpected error: %v", err)
+	}
+	got.Limits, obj.Limits = testLimits{}, testLimits{}
+	if !reflect.DeepEqual(got, obj) {
+		t.Errorf("ToStruct(FromStruct()) = %+v, want %+v", got, obj)
+	}
+}
+
+// testVersion marshals itself to a JSON object with numbers.
+type testVersion struct {
+	Major int
+	Ratio float64
+}
+
+func (v testVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"major": v.Major, "ratio": v.Ratio, "tags": []int{v.Major}})
+}
+
+func (v *testVersion) UnmarshalJSON(data []byte) error {
+	var content struct {
+		Major int     `json:"major"`
+		Ratio float64 `json:"ratio"`
+	}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+	v.Major, v.Ratio = content.Major, content.Ratio
+	return nil
+}
+
+type testMarshalers struct {
+	IP      net.IP       `json:"ip"`
+	Time    metav1.Time  `json:"time"`
+	Updated *metav1.Time `json:"updated,omitempty"`
+	Version testVersion  `json:"version"`
+}
+
+func TestStructMarshalersRoundTrip(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	obj := &testMarshalers{
+		IP:      net.ParseIP("1.2.3.4"),
+		Time:    now,
+		Updated: &now,
+		Version: testVersion{Major: 2, Ratio: 0.5},
+	}
+	m, err := FromStruct(obj)
+	if err != nil {
+		t.Fatalf("FromStruct() unexpected error: %v", err)
+	}
+	wanted := map[string]interface{}{
+		"ip":      "1.2.3.4",
+		"time":    "2024-01-02T03:04:05Z",
+		"updated": "2024-01-02T03:04:05Z",
+		"version": map[string]interface{}{"major": int64(2), "ratio": 0.5, "tags": []interface{}{int64(2)}},
+	}
+	if !reflect.DeepEqual(m, wanted) {
+		t.Errorf("FromStruct() = %v, want %v", m, wanted)
+	}
+
+	got := &testMarshalers{}
+	if err = ToStruct(m, got); err != nil {
+		t.Fatalf("ToStruct() unexpected error: %v", err)
+	}
+	if !got.IP.Equal(obj.IP) || !got.Time.Equal(&obj.Time) || got.Updated == nil || !got.Updated.Equal(obj.Updated) ||
+		got.Version != obj.Version {
+		t.Errorf("ToStruct(FromStruct()) = %+v, want %+v", got, obj)
+	}
+}